@@ -38,21 +38,33 @@ func runNeuralGauntlet(client zenithproto.SearchServiceClient) {
 		{"ENV-06", "Global warming requires environmental solutions and atmospheric carbon capture."},
 	}
 
-	// 1. 📦 DETERMINISTIC INDEXING (No Goroutines here!)
-	// We need these to enter the engine in the exact same order every time
-	// to ensure internal IDs and TF-IDF weights are stable.
+	// 1. 📦 BULK INDEXING
+	// One stream for the whole corpus instead of a round-trip per document.
 	fmt.Printf("📦 Feeding %d documents into Zenith's brain...\n", len(challengingDocs))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	stream, err := client.BulkIndex(ctx)
+	if err != nil {
+		log.Fatalf("❌ Could not open bulk stream: %v", err)
+	}
 	for _, d := range challengingDocs {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		_, err := client.IndexDocuments(ctx, &zenithproto.IndexRequest{
-			Id:   d.id,
-			Data: d.text,
-		})
-		cancel()
-		if err != nil {
+		if err := stream.Send(&zenithproto.BulkOperation{Id: d.id, Data: d.text}); err != nil {
 			log.Fatalf("❌ Critical Indexing Failure for %s: %v", d.id, err)
 		}
 	}
+	summary, err := stream.CloseAndRecv()
+	cancel()
+	if err != nil {
+		log.Fatalf("❌ Critical Indexing Failure: %v", err)
+	}
+	for _, item := range summary.Items {
+		if item.Code != 0 {
+			log.Fatalf("❌ Critical Indexing Failure for %s: %s", item.Id, item.Error)
+		}
+	}
+	fmt.Printf("📦 %d/%d documents indexed in %dms\n", summary.Succeeded, summary.Total, summary.TookMs)
+
+	// Vectors are computed in the background, wait for them before the neural trials.
+	waitForEmbeddings(client, 30*time.Second)
 
 	fmt.Println("✅ Indexing Complete. Memory brain is warm.")
 	fmt.Println("--------------------------------------------------")
@@ -87,7 +99,7 @@ func runNeuralGauntlet(client zenithproto.SearchServiceClient) {
 			query:    "Transfomer",
 			expected: "AI-04",
 			category: "FUZZY",
-			reason:   "Levenshtein Distance 1",
+			reason:   "Damerau-Levenshtein distance 1",
 		},
 	}
 
@@ -95,7 +107,10 @@ func runNeuralGauntlet(client zenithproto.SearchServiceClient) {
 		// Use a fresh context per search
 		ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
 		start := time.Now()
-		res, err := client.Search(ctx, &zenithproto.SearchRequest{Query: test.query})
+		res, err := client.Search(ctx, &zenithproto.SearchRequest{
+			Query:      test.query,
+			SpellCheck: zenithproto.SpellCheck_SPELL_CHECK_SUGGEST,
+		})
 		duration := time.Since(start)
 		cancel()
 
@@ -106,6 +121,12 @@ func runNeuralGauntlet(client zenithproto.SearchServiceClient) {
 
 		fmt.Printf("🔍 Query: [%-15s] | Cat: %-8s | Latency: %v\n", test.query, test.category, duration)
 		fmt.Printf("🎯 Goal:  Match %s (%s)\n", test.expected, test.reason)
+		if res.Suggestion != "" {
+			fmt.Printf("💡 Did you mean: %s\n", res.Suggestion)
+		}
+		if res.SemanticSkipped {
+			fmt.Printf("⚠️  Lexical only: %s\n", res.DegradedReason)
+		}
 
 		if len(res.Results) == 0 {
 			fmt.Println("   🚫 NO RESULTS FOUND")
@@ -129,4 +150,73 @@ func runNeuralGauntlet(client zenithproto.SearchServiceClient) {
 		}
 		fmt.Println("--------------------------------------------------")
 	}
+
+	runSuggestTrials(client)
+	runAnalyze(client, "PageRanc")
+}
+
+func runSuggestTrials(client zenithproto.SearchServiceClient) {
+	for _, prefix := range []string{"ran", "rnak", "transfo", "semntic"} {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		start := time.Now()
+		res, err := client.Suggest(ctx, &zenithproto.SuggestRequest{Prefix: prefix, Fuzzy: true})
+		duration := time.Since(start)
+		cancel()
+
+		if err != nil {
+			fmt.Printf("❌ Suggest Error for [%s]: %v\n", prefix, err)
+			continue
+		}
+
+		var completions []string
+		for _, s := range res.Suggestions {
+			completions = append(completions, s.Text)
+		}
+		fmt.Printf("⌨️  Suggest: [%-8s] -> %v | Latency: %v\n", prefix, completions, duration)
+	}
+}
+
+// runAnalyze shows the tokens a failing query was reduced to.
+func runAnalyze(client zenithproto.SearchServiceClient, text string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	res, err := client.Analyze(ctx, &zenithproto.AnalyzeRequest{Text: text, Search: true})
+	cancel()
+	if err != nil {
+		fmt.Printf("❌ Analyze Error for [%s]: %v\n", text, err)
+		return
+	}
+
+	fmt.Printf("🔬 Analyze [%s] with %s:\n", text, res.Analyzer)
+	for _, t := range res.Tokens {
+		var codes []string
+		for _, p := range t.Phonetic {
+			codes = append(codes, p.Encoder+":"+p.Code)
+		}
+		fmt.Printf("   #%d %q [%d:%d] -> %q ngrams=%v phonetic=%v", t.Position, t.Text, t.Start, t.End, t.Term, t.EdgeNgrams, codes)
+		if len(t.InfixNgrams) > 0 {
+			fmt.Printf(" infix=%v", t.InfixNgrams)
+		}
+		fmt.Println()
+	}
+}
+
+func waitForEmbeddings(client zenithproto.SearchServiceClient, limit time.Duration) {
+	deadline := time.Now().Add(limit)
+	for time.Now().Before(deadline) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		status, err := client.EmbeddingStatus(ctx, &zenithproto.EmbeddingStatusRequest{})
+		cancel()
+		if err != nil {
+			log.Printf("⚠️ Could not read embedding status: %v", err)
+			return
+		}
+		if status.Pending == 0 {
+			if status.Failed > 0 {
+				fmt.Printf("⚠️ %d documents failed to embed, neural trials will be lexical only\n", status.Failed)
+			}
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	fmt.Println("⚠️ Timed out waiting for embeddings")
 }
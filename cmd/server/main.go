@@ -1,11 +1,15 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/shramanb113/ZENITH/gen/go/zenithproto"
 	"github.com/shramanb113/ZENITH/internal/analysis"
@@ -15,25 +19,175 @@ import (
 )
 
 func main() {
+	embedderKind := flag.String("embedder", "nerve", "embedding backend: nerve, hash or static")
+	nerveURL := flag.String("nerve-url", analysis.DefaultNerveURL, "base URL of the Python nerve service")
+	nerveTimeout := flag.Duration("nerve-timeout", 10*time.Second, "timeout for a single nerve request")
+	batchSize := flag.Int("nerve-batch-size", 32, "max texts per nerve batch request (1 disables batching)")
+	batchWait := flag.Duration("nerve-batch-wait", 5*time.Millisecond, "max time to wait for a nerve batch to fill")
+	breakerFailures := flag.Int("breaker-failures", 5, "consecutive embedder failures that open the circuit")
+	breakerOpenFor := flag.Duration("breaker-open-for", 10*time.Second, "how long an open circuit fails fast before probing")
+	semanticBudget := flag.Duration("semantic-budget", 500*time.Millisecond, "max time a search may spend on the semantic leg (0 = caller deadline only)")
+	fusionMethod := flag.String("fusion", string(index.FusionRRF), "default fusion: rrf, convex or dbsf")
+	fusionK := flag.Float64("fusion-k", index.DefaultFusion.K, "default RRF rank constant")
+	fuzzinessFlag := flag.String("fuzziness", "AUTO", "default fuzzy edit budget: AUTO, AUTO:low,high or a distance")
+	phoneticFlag := flag.String("phonetic", "soundex", "comma-separated phonetic encoders for the data field: soundex, double_metaphone, beider_morse (empty disables)")
+	cacheSize := flag.Int("embed-cache-size", 10000, "embeddings kept in the in-memory LRU (0 disables caching)")
+	cacheDir := flag.String("embed-cache-dir", "", "directory for the persistent embedding cache")
+	hashDims := flag.Int("hash-dims", analysis.DefaultHashDims, "dimensions of the hash embedder")
+	vectorsPath := flag.String("vectors", "", "word vector file for the static embedder")
+	indexConfig := flag.String("index-config", "", "JSON file declaring analyzers and the analyzer of each field")
+	schemaPath := flag.String("schema", "", "JSON schema the default index is created with")
+	dataDir := flag.String("data-dir", "data", "directory holding one subdirectory per index")
+	synonymsPath := flag.String("synonyms", "", "synonym file applied to data field queries (reloaded on SIGHUP)")
+	synonymsFormat := flag.String("synonyms-format", "solr", "synonym file format: solr or wordnet")
+	synonymPrecedence := flag.String("synonym-precedence", string(index.SynonymsFirst), "synonyms_first skips neural expansion for words with explicit synonyms; both expands them too")
+	shingles := flag.Int("shingles", 0, "also index and query runs of 2 up to this many adjacent words as one term (0 disables)")
+	decompoundWords := flag.String("decompound-words", "", "word list used to split compounds in the data field")
+	decompoundLinking := flag.String("decompound-linking", "", "comma-separated linking elements allowed between compound parts, e.g. s,es")
+	maxExpansions := flag.Int("max-expansions", index.DefaultMaxExpansions, "max terms one wildcard or regex clause expands to (0 = unlimited)")
+	expansionTimeout := flag.Duration("expansion-timeout", index.DefaultExpansionTimeout, "max time a query spends expanding wildcard and regex clauses (0 = unlimited)")
+	detectLanguage := flag.Bool("detect-language", true, "detect the language of untagged documents and queries")
+	detectMargin := flag.Float64("detect-margin", index.DefaultDetectMargin, "detection margin needed to analyze text as another language than its field's")
+	bulkWorkers := flag.Int("bulk-workers", 0, "operations of a BulkIndex stream applied at once (0 = GOMAXPROCS)")
+	flag.Parse()
+
 	lis, err := net.Listen("tcp", ":8080")
 
 	if err != nil {
 		log.Fatalf("Error occurred : %s", err)
 	}
 
-	idx := index.NewInMemoryIndex()
-	tkz := analysis.NewStandardTokenizer()
+	embedder, err := newEmbedder(*embedderKind, *nerveURL, *nerveTimeout, *hashDims, *vectorsPath)
+	if err != nil {
+		log.Fatalf("Failed to set up embedder: %v", err)
+	}
+
+	if nerve, ok := embedder.(*analysis.NerveEmbedder); ok && *batchSize > 1 {
+		batching := analysis.NewBatchingEmbedder(nerve, analysis.BatchConfig{
+			MaxBatchSize: *batchSize,
+			MaxWait:      *batchWait,
+		})
+		defer batching.Close()
+		embedder = batching
+	}
 
-	if err := idx.Load("zenith.db"); err != nil {
-		log.Println("No existing index found, starting fresh.")
-	} else {
-		log.Println("Successfully loaded index from disk.")
+	// The breaker sits under the cache so cached vectors keep serving while
+	// the nerve is down.
+	embedder = analysis.NewCircuitBreaker(embedder, analysis.BreakerConfig{
+		FailureThreshold: *breakerFailures,
+		OpenFor:          *breakerOpenFor,
+	})
+
+	if *cacheSize > 0 {
+		cached, err := analysis.NewCachedEmbedder(embedder, analysis.CacheConfig{
+			Capacity: *cacheSize,
+			Dir:      *cacheDir,
+		})
+		if err != nil {
+			log.Fatalf("Failed to open embedding cache: %v", err)
+		}
+		defer cached.Close()
+		embedder = cached
+	}
+
+	fusion := index.FusionConfig{Method: index.FusionMethod(*fusionMethod), K: *fusionK}
+	if err := fusion.Validate(); err != nil {
+		log.Fatalf("Invalid fusion settings: %v", err)
+	}
+
+	fuzziness, err := analysis.ParseFuzziness(*fuzzinessFlag)
+	if err != nil {
+		log.Fatalf("Invalid fuzziness: %v", err)
+	}
+
+	var encoders []analysis.PhoneticEncoder
+	for _, name := range strings.Split(*phoneticFlag, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		enc, err := analysis.PhoneticEncoderByName(name)
+		if err != nil {
+			log.Fatalf("Invalid phonetic encoders: %v", err)
+		}
+		encoders = append(encoders, enc)
+	}
+
+	opts := []index.Option{
+		index.WithEmbedder(embedder),
+		index.WithSemanticBudget(*semanticBudget),
+		index.WithFusion(fusion),
+		index.WithFuzziness(fuzziness),
+		index.WithPhonetic(index.DefaultField, encoders...),
+		index.WithExpansionLimits(*maxExpansions, *expansionTimeout),
+	}
+
+	if *detectLanguage {
+		opts = append(opts, index.WithLanguageDetection(analysis.DefaultLanguageDetector, *detectMargin))
+	}
+
+	switch p := index.SynonymPrecedence(*synonymPrecedence); p {
+	case index.SynonymsFirst, index.SynonymsAndNeural:
+		opts = append(opts, index.WithSynonymPrecedence(p))
+	default:
+		log.Fatalf("Invalid synonym precedence %q", *synonymPrecedence)
+	}
+
+	if *indexConfig != "" || *synonymsPath != "" || *shingles > 0 || *decompoundWords != "" {
+		var cfg index.Config
+		if *indexConfig != "" {
+			if cfg, err = index.LoadConfig(*indexConfig); err != nil {
+				log.Fatalf("Failed to load index config: %v", err)
+			}
+		}
+		if *decompoundWords != "" {
+			var linking []string
+			if *decompoundLinking != "" {
+				linking = strings.Split(*decompoundLinking, ",")
+			}
+			cfg.AddDecompounder(index.DefaultField, *decompoundWords, linking)
+		}
+		if *shingles > 0 {
+			cfg.AddShingles(index.DefaultField, *shingles)
+		}
+		if *synonymsPath != "" {
+			cfg.AddSynonyms(index.DefaultField, *synonymsPath, *synonymsFormat)
+		}
+		analyzerOpts, err := cfg.Options(analysis.DefaultRegistry)
+		if err != nil {
+			log.Fatalf("Invalid index config: %v", err)
+		}
+		opts = append(opts, analyzerOpts...)
+	}
+	catalog, err := index.OpenCatalog(*dataDir, opts...)
+	if err != nil {
+		log.Fatalf("Failed to open indexes: %v", err)
+	}
+	log.Printf("Loaded %d indexes from %s", len(catalog.List()), *dataDir)
+
+	if !catalog.Exists(index.DefaultIndex) {
+		var settings index.IndexSettings
+		if *schemaPath != "" {
+			schema, err := index.LoadSchema(*schemaPath)
+			if err != nil {
+				log.Fatalf("Failed to load schema: %v", err)
+			}
+			settings.Schema = &schema
+		}
+		if _, err := catalog.Create(index.DefaultIndex, settings); err != nil {
+			log.Fatalf("Failed to create the default index: %v", err)
+		}
+		// Servers before named indexes kept a single snapshot here.
+		if _, err := os.Stat(legacySnapshot); err == nil {
+			if err := catalog.Import(index.DefaultIndex, legacySnapshot); err != nil {
+				log.Fatalf("Failed to import %s: %v", legacySnapshot, err)
+			}
+		}
 	}
 
 	grpcServer := grpc.NewServer()
 	zenithServer := &server.ZenithServer{
-		Index:     idx,
-		Tokenizer: tkz,
+		Indexes:     catalog,
+		BulkWorkers: *bulkWorkers,
 	}
 
 	zenithproto.RegisterSearchServiceServer(grpcServer, zenithServer)
@@ -50,14 +204,43 @@ func main() {
 		}
 	}()
 
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := analysis.ReloadSynonymFiles(); err != nil {
+				log.Printf("⚠️ %v", err)
+				continue
+			}
+			log.Println("Synonyms reloaded.")
+		}
+	}()
+
 	<-stop
 
 	grpcServer.GracefulStop()
 
-	if err := idx.Save("zenith.db"); err != nil {
-		log.Printf("Failed to save index: %v", err)
+	if err := catalog.Close(); err != nil {
+		log.Printf("Failed to save indexes: %v", err)
 	} else {
-		log.Println("Index saved successfully. Goodbye!")
+		log.Println("Indexes saved successfully. Goodbye!")
 	}
 
 }
+
+const legacySnapshot = "zenith.db"
+
+func newEmbedder(kind, nerveURL string, nerveTimeout time.Duration, hashDims int, vectorsPath string) (analysis.Embedder, error) {
+	switch kind {
+	case "nerve":
+		return analysis.NewNerveEmbedder(analysis.NerveConfig{URL: nerveURL, Timeout: nerveTimeout}), nil
+	case "hash":
+		return analysis.NewHashEmbedder(hashDims), nil
+	case "static":
+		if vectorsPath == "" {
+			return nil, fmt.Errorf("-vectors is required for the static embedder")
+		}
+		return analysis.LoadStaticEmbedder(vectorsPath)
+	}
+	return nil, fmt.Errorf("unknown embedder %q", kind)
+}
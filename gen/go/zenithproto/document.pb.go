@@ -21,29 +21,3090 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SpellCheck int32
+
+const (
+	SpellCheck_SPELL_CHECK_OFF SpellCheck = 0
+	// Return a corrected query in SearchResponse.suggestion.
+	SpellCheck_SPELL_CHECK_SUGGEST SpellCheck = 1
+	// Also search with the correction when the query matched nothing lexically.
+	SpellCheck_SPELL_CHECK_AUTO_CORRECT SpellCheck = 2
+)
+
+// Enum value maps for SpellCheck.
+var (
+	SpellCheck_name = map[int32]string{
+		0: "SPELL_CHECK_OFF",
+		1: "SPELL_CHECK_SUGGEST",
+		2: "SPELL_CHECK_AUTO_CORRECT",
+	}
+	SpellCheck_value = map[string]int32{
+		"SPELL_CHECK_OFF":          0,
+		"SPELL_CHECK_SUGGEST":      1,
+		"SPELL_CHECK_AUTO_CORRECT": 2,
+	}
+)
+
+func (x SpellCheck) Enum() *SpellCheck {
+	p := new(SpellCheck)
+	*p = x
+	return p
+}
+
+func (x SpellCheck) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SpellCheck) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[0].Descriptor()
+}
+
+func (SpellCheck) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[0]
+}
+
+func (x SpellCheck) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SpellCheck.Descriptor instead.
+func (SpellCheck) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{0}
+}
+
+type SuggestSource int32
+
+const (
+	SuggestSource_SUGGEST_TERMS  SuggestSource = 0
+	SuggestSource_SUGGEST_TITLES SuggestSource = 1
+)
+
+// Enum value maps for SuggestSource.
+var (
+	SuggestSource_name = map[int32]string{
+		0: "SUGGEST_TERMS",
+		1: "SUGGEST_TITLES",
+	}
+	SuggestSource_value = map[string]int32{
+		"SUGGEST_TERMS":  0,
+		"SUGGEST_TITLES": 1,
+	}
+)
+
+func (x SuggestSource) Enum() *SuggestSource {
+	p := new(SuggestSource)
+	*p = x
+	return p
+}
+
+func (x SuggestSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SuggestSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[1].Descriptor()
+}
+
+func (SuggestSource) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[1]
+}
+
+func (x SuggestSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SuggestSource.Descriptor instead.
+func (SuggestSource) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{1}
+}
+
+type FusionMethod int32
+
+const (
+	FusionMethod_FUSION_DEFAULT FusionMethod = 0
+	FusionMethod_FUSION_RRF     FusionMethod = 1
+	FusionMethod_FUSION_CONVEX  FusionMethod = 2
+	FusionMethod_FUSION_DBSF    FusionMethod = 3
+)
+
+// Enum value maps for FusionMethod.
+var (
+	FusionMethod_name = map[int32]string{
+		0: "FUSION_DEFAULT",
+		1: "FUSION_RRF",
+		2: "FUSION_CONVEX",
+		3: "FUSION_DBSF",
+	}
+	FusionMethod_value = map[string]int32{
+		"FUSION_DEFAULT": 0,
+		"FUSION_RRF":     1,
+		"FUSION_CONVEX":  2,
+		"FUSION_DBSF":    3,
+	}
+)
+
+func (x FusionMethod) Enum() *FusionMethod {
+	p := new(FusionMethod)
+	*p = x
+	return p
+}
+
+func (x FusionMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FusionMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[2].Descriptor()
+}
+
+func (FusionMethod) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[2]
+}
+
+func (x FusionMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FusionMethod.Descriptor instead.
+func (FusionMethod) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{2}
+}
+
+type ScoreNormalization int32
+
+const (
+	ScoreNormalization_NORMALIZATION_DEFAULT ScoreNormalization = 0
+	ScoreNormalization_NORMALIZATION_MIN_MAX ScoreNormalization = 1
+	ScoreNormalization_NORMALIZATION_Z_SCORE ScoreNormalization = 2
+)
+
+// Enum value maps for ScoreNormalization.
+var (
+	ScoreNormalization_name = map[int32]string{
+		0: "NORMALIZATION_DEFAULT",
+		1: "NORMALIZATION_MIN_MAX",
+		2: "NORMALIZATION_Z_SCORE",
+	}
+	ScoreNormalization_value = map[string]int32{
+		"NORMALIZATION_DEFAULT": 0,
+		"NORMALIZATION_MIN_MAX": 1,
+		"NORMALIZATION_Z_SCORE": 2,
+	}
+)
+
+func (x ScoreNormalization) Enum() *ScoreNormalization {
+	p := new(ScoreNormalization)
+	*p = x
+	return p
+}
+
+func (x ScoreNormalization) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScoreNormalization) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[3].Descriptor()
+}
+
+func (ScoreNormalization) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[3]
+}
+
+func (x ScoreNormalization) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScoreNormalization.Descriptor instead.
+func (ScoreNormalization) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{3}
+}
+
+type FieldType int32
+
+const (
+	FieldType_FIELD_TYPE_UNSPECIFIED FieldType = 0
+	FieldType_FIELD_TEXT             FieldType = 1
+	FieldType_FIELD_KEYWORD          FieldType = 2
+	FieldType_FIELD_NUMBER           FieldType = 3
+	// The embedding of the data field; at most one per schema.
+	FieldType_FIELD_VECTOR FieldType = 4
+)
+
+// Enum value maps for FieldType.
+var (
+	FieldType_name = map[int32]string{
+		0: "FIELD_TYPE_UNSPECIFIED",
+		1: "FIELD_TEXT",
+		2: "FIELD_KEYWORD",
+		3: "FIELD_NUMBER",
+		4: "FIELD_VECTOR",
+	}
+	FieldType_value = map[string]int32{
+		"FIELD_TYPE_UNSPECIFIED": 0,
+		"FIELD_TEXT":             1,
+		"FIELD_KEYWORD":          2,
+		"FIELD_NUMBER":           3,
+		"FIELD_VECTOR":           4,
+	}
+)
+
+func (x FieldType) Enum() *FieldType {
+	p := new(FieldType)
+	*p = x
+	return p
+}
+
+func (x FieldType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FieldType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[4].Descriptor()
+}
+
+func (FieldType) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[4]
+}
+
+func (x FieldType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FieldType.Descriptor instead.
+func (FieldType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{4}
+}
+
+type VectorMetric int32
+
+const (
+	VectorMetric_METRIC_UNSPECIFIED VectorMetric = 0
+	VectorMetric_METRIC_COSINE      VectorMetric = 1
+	VectorMetric_METRIC_DOT_PRODUCT VectorMetric = 2
+	VectorMetric_METRIC_L2          VectorMetric = 3
+)
+
+// Enum value maps for VectorMetric.
+var (
+	VectorMetric_name = map[int32]string{
+		0: "METRIC_UNSPECIFIED",
+		1: "METRIC_COSINE",
+		2: "METRIC_DOT_PRODUCT",
+		3: "METRIC_L2",
+	}
+	VectorMetric_value = map[string]int32{
+		"METRIC_UNSPECIFIED": 0,
+		"METRIC_COSINE":      1,
+		"METRIC_DOT_PRODUCT": 2,
+		"METRIC_L2":          3,
+	}
+)
+
+func (x VectorMetric) Enum() *VectorMetric {
+	p := new(VectorMetric)
+	*p = x
+	return p
+}
+
+func (x VectorMetric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VectorMetric) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[5].Descriptor()
+}
+
+func (VectorMetric) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[5]
+}
+
+func (x VectorMetric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VectorMetric.Descriptor instead.
+func (VectorMetric) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{5}
+}
+
+type ReindexState int32
+
+const (
+	ReindexState_REINDEX_RUNNING ReindexState = 0
+	ReindexState_REINDEX_DONE    ReindexState = 1
+	// Stopped because the source or dest index was deleted.
+	ReindexState_REINDEX_CANCELED ReindexState = 2
+)
+
+// Enum value maps for ReindexState.
+var (
+	ReindexState_name = map[int32]string{
+		0: "REINDEX_RUNNING",
+		1: "REINDEX_DONE",
+		2: "REINDEX_CANCELED",
+	}
+	ReindexState_value = map[string]int32{
+		"REINDEX_RUNNING":  0,
+		"REINDEX_DONE":     1,
+		"REINDEX_CANCELED": 2,
+	}
+)
+
+func (x ReindexState) Enum() *ReindexState {
+	p := new(ReindexState)
+	*p = x
+	return p
+}
+
+func (x ReindexState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReindexState) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[6].Descriptor()
+}
+
+func (ReindexState) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[6]
+}
+
+func (x ReindexState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReindexState.Descriptor instead.
+func (ReindexState) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{6}
+}
+
+type BulkOperation_Type int32
+
+const (
+	BulkOperation_INDEX  BulkOperation_Type = 0 // add or replace the document
+	BulkOperation_UPDATE BulkOperation_Type = 1 // change a stored document; unset fields keep their value
+	BulkOperation_DELETE BulkOperation_Type = 2
+)
+
+// Enum value maps for BulkOperation_Type.
+var (
+	BulkOperation_Type_name = map[int32]string{
+		0: "INDEX",
+		1: "UPDATE",
+		2: "DELETE",
+	}
+	BulkOperation_Type_value = map[string]int32{
+		"INDEX":  0,
+		"UPDATE": 1,
+		"DELETE": 2,
+	}
+)
+
+func (x BulkOperation_Type) Enum() *BulkOperation_Type {
+	p := new(BulkOperation_Type)
+	*p = x
+	return p
+}
+
+func (x BulkOperation_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BulkOperation_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[7].Descriptor()
+}
+
+func (BulkOperation_Type) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[7]
+}
+
+func (x BulkOperation_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BulkOperation_Type.Descriptor instead.
+func (BulkOperation_Type) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type AliasAction_Type int32
+
+const (
+	AliasAction_ADD    AliasAction_Type = 0
+	AliasAction_REMOVE AliasAction_Type = 1
+)
+
+// Enum value maps for AliasAction_Type.
+var (
+	AliasAction_Type_name = map[int32]string{
+		0: "ADD",
+		1: "REMOVE",
+	}
+	AliasAction_Type_value = map[string]int32{
+		"ADD":    0,
+		"REMOVE": 1,
+	}
+)
+
+func (x AliasAction_Type) Enum() *AliasAction_Type {
+	p := new(AliasAction_Type)
+	*p = x
+	return p
+}
+
+func (x AliasAction_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AliasAction_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_document_proto_enumTypes[8].Descriptor()
+}
+
+func (AliasAction_Type) Type() protoreflect.EnumType {
+	return &file_internal_proto_document_proto_enumTypes[8]
+}
+
+func (x AliasAction_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AliasAction_Type.Descriptor instead.
+func (AliasAction_Type) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{40, 0}
+}
+
 type IndexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data  string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Optional, used for title completions in Suggest.
+	Title      string  `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Popularity float64 `protobuf:"fixed64,4,opt,name=popularity,proto3" json:"popularity,omitempty"`
+	// Language of the document, as a name ("german") or ISO 639-1 code
+	// ("de"). Empty uses the field's analyzer.
+	Language string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	// Further fields, which must be declared in the schema if the index
+	// has one.
+	Fields map[string]string `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The index to use, or an alias pointing to one index; empty means
+	// "default", created on first use.
+	Index         string `protobuf:"bytes,7,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexRequest) Reset() {
+	*x = IndexRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexRequest) ProtoMessage() {}
+
+func (x *IndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexRequest.ProtoReflect.Descriptor instead.
+func (*IndexRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IndexRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IndexRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *IndexRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *IndexRequest) GetPopularity() float64 {
+	if x != nil {
+		return x.Popularity
+	}
+	return 0
+}
+
+func (x *IndexRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *IndexRequest) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *IndexRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type IndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        bool                   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexResponse) Reset() {
+	*x = IndexResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexResponse) ProtoMessage() {}
+
+func (x *IndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexResponse.ProtoReflect.Descriptor instead.
+func (*IndexResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IndexResponse) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+func (x *IndexResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// BulkOperation is one write of a BulkIndex stream. Operations on the same
+// id are applied in stream order; others may be applied concurrently.
+type BulkOperation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  BulkOperation_Type     `protobuf:"varint,1,opt,name=type,proto3,enum=zenith.BulkOperation_Type" json:"type,omitempty"`
+	Index string                 `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	Id    string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// For UPDATE, empty keeps the stored text.
+	Data          string            `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Title         string            `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Popularity    *float64          `protobuf:"fixed64,6,opt,name=popularity,proto3,oneof" json:"popularity,omitempty"`
+	Language      string            `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	Fields        map[string]string `protobuf:"bytes,8,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkOperation) Reset() {
+	*x = BulkOperation{}
+	mi := &file_internal_proto_document_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkOperation) ProtoMessage() {}
+
+func (x *BulkOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkOperation.ProtoReflect.Descriptor instead.
+func (*BulkOperation) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BulkOperation) GetType() BulkOperation_Type {
+	if x != nil {
+		return x.Type
+	}
+	return BulkOperation_INDEX
+}
+
+func (x *BulkOperation) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *BulkOperation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkOperation) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *BulkOperation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BulkOperation) GetPopularity() float64 {
+	if x != nil && x.Popularity != nil {
+		return *x.Popularity
+	}
+	return 0
+}
+
+func (x *BulkOperation) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *BulkOperation) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type BulkItemResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the operation in the stream, from 0.
+	Seq int64  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Id  string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// A google.rpc.Code; 0 (OK) if the operation was applied.
+	Code          int32  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkItemResult) Reset() {
+	*x = BulkItemResult{}
+	mi := &file_internal_proto_document_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkItemResult) ProtoMessage() {}
+
+func (x *BulkItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkItemResult.ProtoReflect.Descriptor instead.
+func (*BulkItemResult) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BulkItemResult) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *BulkItemResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkItemResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BulkItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BulkIndexResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per operation, in stream order.
+	Items         []*BulkItemResult `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int64             `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Succeeded     int64             `protobuf:"varint,3,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int64             `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	TookMs        int64             `protobuf:"varint,5,opt,name=took_ms,json=tookMs,proto3" json:"took_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkIndexResponse) Reset() {
+	*x = BulkIndexResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkIndexResponse) ProtoMessage() {}
+
+func (x *BulkIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkIndexResponse.ProtoReflect.Descriptor instead.
+func (*BulkIndexResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BulkIndexResponse) GetItems() []*BulkItemResult {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BulkIndexResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BulkIndexResponse) GetSucceeded() int64 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BulkIndexResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkIndexResponse) GetTookMs() int64 {
+	if x != nil {
+		return x.TookMs
+	}
+	return 0
+}
+
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Words, wildcard (pag*) and /regex/ clauses, and name:value or
+	// name:"some words" clauses matching one indexed field of the schema.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Unset fields fall back to the index's default fusion.
+	Fusion *Fusion `protobuf:"bytes,2,opt,name=fusion,proto3" json:"fusion,omitempty"`
+	// Attach a score breakdown to every result.
+	Explain bool `protobuf:"varint,3,opt,name=explain,proto3" json:"explain,omitempty"`
+	// Attach highlighted fragments to every result.
+	Highlight  *Highlight `protobuf:"bytes,4,opt,name=highlight,proto3" json:"highlight,omitempty"`
+	SpellCheck SpellCheck `protobuf:"varint,5,opt,name=spell_check,json=spellCheck,proto3,enum=zenith.SpellCheck" json:"spell_check,omitempty"`
+	// Edit budget for fuzzy matching: "AUTO", "AUTO:low,high" or a distance
+	// such as "0" (off), "1" or "1.5". Empty uses the index default.
+	Fuzziness string `protobuf:"bytes,6,opt,name=fuzziness,proto3" json:"fuzziness,omitempty"`
+	// Analyze the query as this language; see IndexRequest.language.
+	Language string `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	// An index, or an alias whose indexes are all searched.
+	Index         string `protobuf:"bytes,8,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetFusion() *Fusion {
+	if x != nil {
+		return x.Fusion
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+func (x *SearchRequest) GetHighlight() *Highlight {
+	if x != nil {
+		return x.Highlight
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetSpellCheck() SpellCheck {
+	if x != nil {
+		return x.SpellCheck
+	}
+	return SpellCheck_SPELL_CHECK_OFF
+}
+
+func (x *SearchRequest) GetFuzziness() string {
+	if x != nil {
+		return x.Fuzziness
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type SuggestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Source        SuggestSource          `protobuf:"varint,2,opt,name=source,proto3,enum=zenith.SuggestSource" json:"source,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`   // defaults to 5
+	Fuzzy         bool                   `protobuf:"varint,4,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"` // tolerate typos in prefixes of 3 or more characters
+	Index         string                 `protobuf:"bytes,5,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRequest) Reset() {
+	*x = SuggestRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRequest) ProtoMessage() {}
+
+func (x *SuggestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SuggestRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestRequest) GetSource() SuggestSource {
+	if x != nil {
+		return x.Source
+	}
+	return SuggestSource_SUGGEST_TERMS
+}
+
+func (x *SuggestRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SuggestRequest) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+func (x *SuggestRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"` // set for title completions
+	Weight        float64                `protobuf:"fixed64,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Distance      int32                  `protobuf:"varint,4,opt,name=distance,proto3" json:"distance,omitempty"` // edits between the prefix and the completion
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_internal_proto_document_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Suggestion) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Suggestion) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type SuggestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestResponse) Reset() {
+	*x = SuggestResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestResponse) ProtoMessage() {}
+
+func (x *SuggestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestResponse.ProtoReflect.Descriptor instead.
+func (*SuggestResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SuggestResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type Highlight struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PreTag            string                 `protobuf:"bytes,1,opt,name=pre_tag,json=preTag,proto3" json:"pre_tag,omitempty"`                                     // defaults to <em>
+	PostTag           string                 `protobuf:"bytes,2,opt,name=post_tag,json=postTag,proto3" json:"post_tag,omitempty"`                                  // defaults to </em>
+	FragmentSize      int32                  `protobuf:"varint,3,opt,name=fragment_size,json=fragmentSize,proto3" json:"fragment_size,omitempty"`                  // approximate bytes per fragment, defaults to 100
+	NumberOfFragments int32                  `protobuf:"varint,4,opt,name=number_of_fragments,json=numberOfFragments,proto3" json:"number_of_fragments,omitempty"` // defaults to 3
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Highlight) Reset() {
+	*x = Highlight{}
+	mi := &file_internal_proto_document_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Highlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Highlight) ProtoMessage() {}
+
+func (x *Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Highlight.ProtoReflect.Descriptor instead.
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Highlight) GetPreTag() string {
+	if x != nil {
+		return x.PreTag
+	}
+	return ""
+}
+
+func (x *Highlight) GetPostTag() string {
+	if x != nil {
+		return x.PostTag
+	}
+	return ""
+}
+
+func (x *Highlight) GetFragmentSize() int32 {
+	if x != nil {
+		return x.FragmentSize
+	}
+	return 0
+}
+
+func (x *Highlight) GetNumberOfFragments() int32 {
+	if x != nil {
+		return x.NumberOfFragments
+	}
+	return 0
+}
+
+type Fusion struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Method FusionMethod           `protobuf:"varint,1,opt,name=method,proto3,enum=zenith.FusionMethod" json:"method,omitempty"`
+	K      float64                `protobuf:"fixed64,2,opt,name=k,proto3" json:"k,omitempty"` // RRF rank constant
+	// Unset weights keep the default; 0 turns the leg off.
+	KeywordWeight *float64           `protobuf:"fixed64,3,opt,name=keyword_weight,json=keywordWeight,proto3,oneof" json:"keyword_weight,omitempty"`
+	VectorWeight  *float64           `protobuf:"fixed64,4,opt,name=vector_weight,json=vectorWeight,proto3,oneof" json:"vector_weight,omitempty"`
+	Normalization ScoreNormalization `protobuf:"varint,5,opt,name=normalization,proto3,enum=zenith.ScoreNormalization" json:"normalization,omitempty"` // convex only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fusion) Reset() {
+	*x = Fusion{}
+	mi := &file_internal_proto_document_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fusion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fusion) ProtoMessage() {}
+
+func (x *Fusion) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fusion.ProtoReflect.Descriptor instead.
+func (*Fusion) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Fusion) GetMethod() FusionMethod {
+	if x != nil {
+		return x.Method
+	}
+	return FusionMethod_FUSION_DEFAULT
+}
+
+func (x *Fusion) GetK() float64 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+func (x *Fusion) GetKeywordWeight() float64 {
+	if x != nil && x.KeywordWeight != nil {
+		return *x.KeywordWeight
+	}
+	return 0
+}
+
+func (x *Fusion) GetVectorWeight() float64 {
+	if x != nil && x.VectorWeight != nil {
+		return *x.VectorWeight
+	}
+	return 0
+}
+
+func (x *Fusion) GetNormalization() ScoreNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return ScoreNormalization_NORMALIZATION_DEFAULT
+}
+
+type SearchResult struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score       float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Explanation *Explanation           `protobuf:"bytes,3,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	// Best passage first.
+	Highlights    []string `protobuf:"bytes,4,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_internal_proto_document_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetExplanation() *Explanation {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+func (x *SearchResult) GetHighlights() []string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+// A node of a score breakdown: value is what this node contributed and
+// details are the parts it was computed from.
+type Explanation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Details       []*Explanation         `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Explanation) Reset() {
+	*x = Explanation{}
+	mi := &file_internal_proto_document_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Explanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Explanation) ProtoMessage() {}
+
+func (x *Explanation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Explanation.ProtoReflect.Descriptor instead.
+func (*Explanation) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Explanation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Explanation) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Explanation) GetDetails() []*Explanation {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type ExplainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Fusion        *Fusion                `protobuf:"bytes,3,opt,name=fusion,proto3" json:"fusion,omitempty"`
+	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	Index         string                 `protobuf:"bytes,5,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainRequest) Reset() {
+	*x = ExplainRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainRequest) ProtoMessage() {}
+
+func (x *ExplainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainRequest.ProtoReflect.Descriptor instead.
+func (*ExplainRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExplainRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ExplainRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExplainRequest) GetFusion() *Fusion {
+	if x != nil {
+		return x.Fusion
+	}
+	return nil
+}
+
+func (x *ExplainRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ExplainRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type ExplainResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1-based position in the full ranking, 0 if the document did not match.
+	Rank          int32        `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Explanation   *Explanation `protobuf:"bytes,2,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainResponse) Reset() {
+	*x = ExplainResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainResponse) ProtoMessage() {}
+
+func (x *ExplainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainResponse.ProtoReflect.Descriptor instead.
+func (*ExplainResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExplainResponse) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ExplainResponse) GetExplanation() *Explanation {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+type SearchResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Set when the semantic leg was skipped (nerve down, circuit open or
+	// latency budget spent) and results are ranked lexically only.
+	SemanticSkipped bool   `protobuf:"varint,2,opt,name=semantic_skipped,json=semanticSkipped,proto3" json:"semantic_skipped,omitempty"`
+	DegradedReason  string `protobuf:"bytes,3,opt,name=degraded_reason,json=degradedReason,proto3" json:"degraded_reason,omitempty"`
+	// "Did you mean": the corrected query, empty if nothing looked misspelled.
+	Suggestion string `protobuf:"bytes,4,opt,name=suggestion,proto3" json:"suggestion,omitempty"`
+	// The results are for the suggestion, not the original query.
+	AutoCorrected bool `protobuf:"varint,5,opt,name=auto_corrected,json=autoCorrected,proto3" json:"auto_corrected,omitempty"`
+	// The language the query was analyzed as, given or detected; empty if
+	// it was not known.
+	Language string `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	// A wildcard or /regex/ clause matched more terms than the limit, or ran
+	// out of time, and only part of them were searched.
+	ExpansionTruncated bool `protobuf:"varint,7,opt,name=expansion_truncated,json=expansionTruncated,proto3" json:"expansion_truncated,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetSemanticSkipped() bool {
+	if x != nil {
+		return x.SemanticSkipped
+	}
+	return false
+}
+
+func (x *SearchResponse) GetDegradedReason() string {
+	if x != nil {
+		return x.DegradedReason
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetSuggestion() string {
+	if x != nil {
+		return x.Suggestion
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetAutoCorrected() bool {
+	if x != nil {
+		return x.AutoCorrected
+	}
+	return false
+}
+
+func (x *SearchResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetExpansionTruncated() bool {
+	if x != nil {
+		return x.ExpansionTruncated
+	}
+	return false
+}
+
+// Analyze runs text through an analyzer without indexing it. Name either an
+// analyzer or a field; a field uses its index analyzer unless search is set.
+type AnalyzeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Analyzer      string                 `protobuf:"bytes,2,opt,name=analyzer,proto3" json:"analyzer,omitempty"`
+	Field         string                 `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	Search        bool                   `protobuf:"varint,4,opt,name=search,proto3" json:"search,omitempty"`
+	Index         string                 `protobuf:"bytes,5,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRequest) Reset() {
+	*x = AnalyzeRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRequest) ProtoMessage() {}
+
+func (x *AnalyzeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AnalyzeRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetAnalyzer() string {
+	if x != nil {
+		return x.Analyzer
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetSearch() bool {
+	if x != nil {
+		return x.Search
+	}
+	return false
+}
+
+func (x *AnalyzeRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type PhoneticCode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Encoder       string                 `protobuf:"bytes,1,opt,name=encoder,proto3" json:"encoder,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhoneticCode) Reset() {
+	*x = PhoneticCode{}
+	mi := &file_internal_proto_document_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhoneticCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhoneticCode) ProtoMessage() {}
+
+func (x *PhoneticCode) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhoneticCode.ProtoReflect.Descriptor instead.
+func (*PhoneticCode) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PhoneticCode) GetEncoder() string {
+	if x != nil {
+		return x.Encoder
+	}
+	return ""
+}
+
+func (x *PhoneticCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type AnalyzedToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Term  string                 `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	// The original text the token covers.
+	Text          string          `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Start         int32           `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32           `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	Position      int32           `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	Keyword       bool            `protobuf:"varint,6,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	EdgeNgrams    []string        `protobuf:"bytes,7,rep,name=edge_ngrams,json=edgeNgrams,proto3" json:"edge_ngrams,omitempty"`
+	Phonetic      []*PhoneticCode `protobuf:"bytes,8,rep,name=phonetic,proto3" json:"phonetic,omitempty"`
+	InfixNgrams   []string        `protobuf:"bytes,9,rep,name=infix_ngrams,json=infixNgrams,proto3" json:"infix_ngrams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzedToken) Reset() {
+	*x = AnalyzedToken{}
+	mi := &file_internal_proto_document_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzedToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzedToken) ProtoMessage() {}
+
+func (x *AnalyzedToken) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzedToken.ProtoReflect.Descriptor instead.
+func (*AnalyzedToken) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AnalyzedToken) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *AnalyzedToken) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *AnalyzedToken) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *AnalyzedToken) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *AnalyzedToken) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *AnalyzedToken) GetKeyword() bool {
+	if x != nil {
+		return x.Keyword
+	}
+	return false
+}
+
+func (x *AnalyzedToken) GetEdgeNgrams() []string {
+	if x != nil {
+		return x.EdgeNgrams
+	}
+	return nil
+}
+
+func (x *AnalyzedToken) GetPhonetic() []*PhoneticCode {
+	if x != nil {
+		return x.Phonetic
+	}
+	return nil
+}
+
+func (x *AnalyzedToken) GetInfixNgrams() []string {
+	if x != nil {
+		return x.InfixNgrams
+	}
+	return nil
+}
+
+type AnalyzeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Analyzer      string                 `protobuf:"bytes,1,opt,name=analyzer,proto3" json:"analyzer,omitempty"`
+	Tokens        []*AnalyzedToken       `protobuf:"bytes,2,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeResponse) Reset() {
+	*x = AnalyzeResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeResponse) ProtoMessage() {}
+
+func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AnalyzeResponse) GetAnalyzer() string {
+	if x != nil {
+		return x.Analyzer
+	}
+	return ""
+}
+
+func (x *AnalyzeResponse) GetTokens() []*AnalyzedToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type EmbeddingStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         string                 `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingStatusRequest) Reset() {
+	*x = EmbeddingStatusRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingStatusRequest) ProtoMessage() {}
+
+func (x *EmbeddingStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingStatusRequest.ProtoReflect.Descriptor instead.
+func (*EmbeddingStatusRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *EmbeddingStatusRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type EmbeddingFailure struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Attempts        int32                  `protobuf:"varint,2,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Error           string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	LastAttemptUnix int64                  `protobuf:"varint,4,opt,name=last_attempt_unix,json=lastAttemptUnix,proto3" json:"last_attempt_unix,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EmbeddingFailure) Reset() {
+	*x = EmbeddingFailure{}
+	mi := &file_internal_proto_document_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingFailure) ProtoMessage() {}
+
+func (x *EmbeddingFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingFailure.ProtoReflect.Descriptor instead.
+func (*EmbeddingFailure) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EmbeddingFailure) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EmbeddingFailure) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *EmbeddingFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *EmbeddingFailure) GetLastAttemptUnix() int64 {
+	if x != nil {
+		return x.LastAttemptUnix
+	}
+	return 0
+}
+
+type EmbeddingCacheStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          uint64                 `protobuf:"varint,1,opt,name=hits,proto3" json:"hits,omitempty"`
+	DiskHits      uint64                 `protobuf:"varint,2,opt,name=disk_hits,json=diskHits,proto3" json:"disk_hits,omitempty"`
+	Misses        uint64                 `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	Entries       int64                  `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingCacheStats) Reset() {
+	*x = EmbeddingCacheStats{}
+	mi := &file_internal_proto_document_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingCacheStats) ProtoMessage() {}
+
+func (x *EmbeddingCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingCacheStats.ProtoReflect.Descriptor instead.
+func (*EmbeddingCacheStats) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *EmbeddingCacheStats) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *EmbeddingCacheStats) GetDiskHits() uint64 {
+	if x != nil {
+		return x.DiskHits
+	}
+	return 0
+}
+
+func (x *EmbeddingCacheStats) GetMisses() uint64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *EmbeddingCacheStats) GetEntries() int64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+type EmbeddingStatusResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Pending   int64                  `protobuf:"varint,1,opt,name=pending,proto3" json:"pending,omitempty"`
+	Converted int64                  `protobuf:"varint,2,opt,name=converted,proto3" json:"converted,omitempty"`
+	Queued    int64                  `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`
+	Failures  []*EmbeddingFailure    `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+	Cache     *EmbeddingCacheStats   `protobuf:"bytes,5,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Documents whose embedding ran out of retries; they are searched
+	// lexically only and listed in failures.
+	Failed        int64 `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingStatusResponse) Reset() {
+	*x = EmbeddingStatusResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingStatusResponse) ProtoMessage() {}
+
+func (x *EmbeddingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingStatusResponse.ProtoReflect.Descriptor instead.
+func (*EmbeddingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EmbeddingStatusResponse) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *EmbeddingStatusResponse) GetConverted() int64 {
+	if x != nil {
+		return x.Converted
+	}
+	return 0
+}
+
+func (x *EmbeddingStatusResponse) GetQueued() int64 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *EmbeddingStatusResponse) GetFailures() []*EmbeddingFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *EmbeddingStatusResponse) GetCache() *EmbeddingCacheStats {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+func (x *EmbeddingStatusResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type DocumentProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fields        map[string]string      `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Vectors       map[string]*Vector     `protobuf:"bytes,3,rep,name=vectors,proto3" json:"vectors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata      []byte                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentProto) Reset() {
+	*x = DocumentProto{}
+	mi := &file_internal_proto_document_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentProto) ProtoMessage() {}
+
+func (x *DocumentProto) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentProto.ProtoReflect.Descriptor instead.
+func (*DocumentProto) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DocumentProto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DocumentProto) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *DocumentProto) GetVectors() map[string]*Vector {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
+func (x *DocumentProto) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DocumentProto) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DocumentProto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Vector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Elements      []float32              `protobuf:"fixed32,1,rep,packed,name=elements,proto3" json:"elements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vector) Reset() {
+	*x = Vector{}
+	mi := &file_internal_proto_document_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vector) ProtoMessage() {}
+
+func (x *Vector) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vector.ProtoReflect.Descriptor instead.
+func (*Vector) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Vector) GetElements() []float32 {
+	if x != nil {
+		return x.Elements
+	}
+	return nil
+}
+
+type FieldMapping struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type  FieldType              `protobuf:"varint,2,opt,name=type,proto3,enum=zenith.FieldType" json:"type,omitempty"`
+	// Text fields only; search_analyzer defaults to analyzer.
+	Analyzer       string `protobuf:"bytes,3,opt,name=analyzer,proto3" json:"analyzer,omitempty"`
+	SearchAnalyzer string `protobuf:"bytes,4,opt,name=search_analyzer,json=searchAnalyzer,proto3" json:"search_analyzer,omitempty"`
+	// Both default to true when unset.
+	Stored  *bool `protobuf:"varint,5,opt,name=stored,proto3,oneof" json:"stored,omitempty"`
+	Indexed *bool `protobuf:"varint,6,opt,name=indexed,proto3,oneof" json:"indexed,omitempty"`
+	// Vector fields only; metric defaults to cosine.
+	Dims   int32        `protobuf:"varint,7,opt,name=dims,proto3" json:"dims,omitempty"`
+	Metric VectorMetric `protobuf:"varint,8,opt,name=metric,proto3,enum=zenith.VectorMetric" json:"metric,omitempty"`
+	// Text fields only; unset uses the index configuration's n-grams.
+	Ngrams        *NGrams `protobuf:"bytes,9,opt,name=ngrams,proto3" json:"ngrams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldMapping) Reset() {
+	*x = FieldMapping{}
+	mi := &file_internal_proto_document_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldMapping) ProtoMessage() {}
+
+func (x *FieldMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldMapping.ProtoReflect.Descriptor instead.
+func (*FieldMapping) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FieldMapping) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FieldMapping) GetType() FieldType {
+	if x != nil {
+		return x.Type
+	}
+	return FieldType_FIELD_TYPE_UNSPECIFIED
+}
+
+func (x *FieldMapping) GetAnalyzer() string {
+	if x != nil {
+		return x.Analyzer
+	}
+	return ""
+}
+
+func (x *FieldMapping) GetSearchAnalyzer() string {
+	if x != nil {
+		return x.SearchAnalyzer
+	}
+	return ""
+}
+
+func (x *FieldMapping) GetStored() bool {
+	if x != nil && x.Stored != nil {
+		return *x.Stored
+	}
+	return false
+}
+
+func (x *FieldMapping) GetIndexed() bool {
+	if x != nil && x.Indexed != nil {
+		return *x.Indexed
+	}
+	return false
+}
+
+func (x *FieldMapping) GetDims() int32 {
+	if x != nil {
+		return x.Dims
+	}
+	return 0
+}
+
+func (x *FieldMapping) GetMetric() VectorMetric {
+	if x != nil {
+		return x.Metric
+	}
+	return VectorMetric_METRIC_UNSPECIFIED
+}
+
+func (x *FieldMapping) GetNgrams() *NGrams {
+	if x != nil {
+		return x.Ngrams
+	}
+	return nil
+}
+
+// Edge n-grams are prefixes of a term, infix n-grams its substrings. A zero
+// max turns that kind off.
+type NGrams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EdgeMin       int32                  `protobuf:"varint,1,opt,name=edge_min,json=edgeMin,proto3" json:"edge_min,omitempty"`
+	EdgeMax       int32                  `protobuf:"varint,2,opt,name=edge_max,json=edgeMax,proto3" json:"edge_max,omitempty"`
+	InfixMin      int32                  `protobuf:"varint,3,opt,name=infix_min,json=infixMin,proto3" json:"infix_min,omitempty"`
+	InfixMax      int32                  `protobuf:"varint,4,opt,name=infix_max,json=infixMax,proto3" json:"infix_max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NGrams) Reset() {
+	*x = NGrams{}
+	mi := &file_internal_proto_document_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NGrams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NGrams) ProtoMessage() {}
+
+func (x *NGrams) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NGrams.ProtoReflect.Descriptor instead.
+func (*NGrams) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *NGrams) GetEdgeMin() int32 {
+	if x != nil {
+		return x.EdgeMin
+	}
+	return 0
+}
+
+func (x *NGrams) GetEdgeMax() int32 {
+	if x != nil {
+		return x.EdgeMax
+	}
+	return 0
+}
+
+func (x *NGrams) GetInfixMin() int32 {
+	if x != nil {
+		return x.InfixMin
+	}
+	return 0
+}
+
+func (x *NGrams) GetInfixMax() int32 {
+	if x != nil {
+		return x.InfixMax
+	}
+	return 0
+}
+
+type Schema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        []*FieldMapping        `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schema) Reset() {
+	*x = Schema{}
+	mi := &file_internal_proto_document_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schema) ProtoMessage() {}
+
+func (x *Schema) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schema.ProtoReflect.Descriptor instead.
+func (*Schema) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Schema) GetFields() []*FieldMapping {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Schema) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// CreateIndex adds an empty index. Names are up to 64 lower-case letters,
+// digits, - and _.
+type CreateIndexRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional; without one the index accepts any field.
+	Schema *Schema `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Index  string  `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	// Optional JSON analysis configuration of the index, in the format of
+	// the server's -index-config file.
+	ConfigJson    string `protobuf:"bytes,3,opt,name=config_json,json=configJson,proto3" json:"config_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIndexRequest) Reset() {
+	*x = CreateIndexRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIndexRequest) ProtoMessage() {}
+
+func (x *CreateIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIndexRequest.ProtoReflect.Descriptor instead.
+func (*CreateIndexRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateIndexRequest) GetSchema() *Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *CreateIndexRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *CreateIndexRequest) GetConfigJson() string {
+	if x != nil {
+		return x.ConfigJson
+	}
+	return ""
+}
+
+type CreateIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *Schema                `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIndexResponse) Reset() {
+	*x = CreateIndexResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIndexResponse) ProtoMessage() {}
+
+func (x *CreateIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIndexResponse.ProtoReflect.Descriptor instead.
+func (*CreateIndexResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateIndexResponse) GetSchema() *Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+// PutMapping adds fields, or changes the search analyzer or stored flag of
+// declared ones; anything else needs a new index.
+type PutMappingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        []*FieldMapping        `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	Index         string                 `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutMappingRequest) Reset() {
+	*x = PutMappingRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutMappingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutMappingRequest) ProtoMessage() {}
+
+func (x *PutMappingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutMappingRequest.ProtoReflect.Descriptor instead.
+func (*PutMappingRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PutMappingRequest) GetFields() []*FieldMapping {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *PutMappingRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type PutMappingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *Schema                `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutMappingResponse) Reset() {
+	*x = PutMappingResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutMappingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutMappingResponse) ProtoMessage() {}
+
+func (x *PutMappingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutMappingResponse.ProtoReflect.Descriptor instead.
+func (*PutMappingResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PutMappingResponse) GetSchema() *Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type GetMappingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         string                 `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMappingRequest) Reset() {
+	*x = GetMappingRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMappingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMappingRequest) ProtoMessage() {}
+
+func (x *GetMappingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMappingRequest.ProtoReflect.Descriptor instead.
+func (*GetMappingRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetMappingRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type GetMappingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *Schema                `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMappingResponse) Reset() {
+	*x = GetMappingResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMappingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMappingResponse) ProtoMessage() {}
+
+func (x *GetMappingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMappingResponse.ProtoReflect.Descriptor instead.
+func (*GetMappingResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetMappingResponse) GetSchema() *Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type DeleteIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         string                 `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIndexRequest) Reset() {
+	*x = DeleteIndexRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIndexRequest) ProtoMessage() {}
+
+func (x *DeleteIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIndexRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIndexRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteIndexRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+type DeleteIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIndexResponse) Reset() {
+	*x = DeleteIndexResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIndexResponse) ProtoMessage() {}
+
+func (x *DeleteIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIndexResponse.ProtoReflect.Descriptor instead.
+func (*DeleteIndexResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{36}
+}
+
+type ListIndexesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIndexesRequest) Reset() {
+	*x = ListIndexesRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIndexesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIndexesRequest) ProtoMessage() {}
+
+func (x *ListIndexesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIndexesRequest.ProtoReflect.Descriptor instead.
+func (*ListIndexesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{37}
+}
+
+type IndexInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Documents int64                  `protobuf:"varint,2,opt,name=documents,proto3" json:"documents,omitempty"`
+	// Documents still waiting for vectors.
+	Pending int64 `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	// 0 if the index has no schema.
+	SchemaVersion int64    `protobuf:"varint,4,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Aliases       []string `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexInfo) Reset() {
+	*x = IndexInfo{}
+	mi := &file_internal_proto_document_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexInfo) ProtoMessage() {}
+
+func (x *IndexInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexInfo.ProtoReflect.Descriptor instead.
+func (*IndexInfo) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *IndexInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IndexInfo) GetDocuments() int64 {
+	if x != nil {
+		return x.Documents
+	}
+	return 0
+}
+
+func (x *IndexInfo) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *IndexInfo) GetSchemaVersion() int64 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *IndexInfo) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+type ListIndexesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Indexes       []*IndexInfo           `protobuf:"bytes,1,rep,name=indexes,proto3" json:"indexes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IndexRequest) Reset() {
-	*x = IndexRequest{}
-	mi := &file_internal_proto_document_proto_msgTypes[0]
+func (x *ListIndexesResponse) Reset() {
+	*x = ListIndexesResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IndexRequest) String() string {
+func (x *ListIndexesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IndexRequest) ProtoMessage() {}
+func (*ListIndexesResponse) ProtoMessage() {}
 
-func (x *IndexRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_document_proto_msgTypes[0]
+func (x *ListIndexesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -54,48 +3115,42 @@ func (x *IndexRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IndexRequest.ProtoReflect.Descriptor instead.
-func (*IndexRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_document_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *IndexRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use ListIndexesResponse.ProtoReflect.Descriptor instead.
+func (*ListIndexesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *IndexRequest) GetData() string {
+func (x *ListIndexesResponse) GetIndexes() []*IndexInfo {
 	if x != nil {
-		return x.Data
+		return x.Indexes
 	}
-	return ""
+	return nil
 }
 
-type IndexResponse struct {
+type AliasAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        bool                   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Type          AliasAction_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=zenith.AliasAction_Type" json:"type,omitempty"`
+	Alias         string                 `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	Index         string                 `protobuf:"bytes,3,opt,name=index,proto3" json:"index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IndexResponse) Reset() {
-	*x = IndexResponse{}
-	mi := &file_internal_proto_document_proto_msgTypes[1]
+func (x *AliasAction) Reset() {
+	*x = AliasAction{}
+	mi := &file_internal_proto_document_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IndexResponse) String() string {
+func (x *AliasAction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IndexResponse) ProtoMessage() {}
+func (*AliasAction) ProtoMessage() {}
 
-func (x *IndexResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_document_proto_msgTypes[1]
+func (x *AliasAction) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,47 +3161,57 @@ func (x *IndexResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IndexResponse.ProtoReflect.Descriptor instead.
-func (*IndexResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_document_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use AliasAction.ProtoReflect.Descriptor instead.
+func (*AliasAction) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *IndexResponse) GetStatus() bool {
+func (x *AliasAction) GetType() AliasAction_Type {
 	if x != nil {
-		return x.Status
+		return x.Type
 	}
-	return false
+	return AliasAction_ADD
 }
 
-func (x *IndexResponse) GetMessage() string {
+func (x *AliasAction) GetAlias() string {
 	if x != nil {
-		return x.Message
+		return x.Alias
 	}
 	return ""
 }
 
-type SearchRequest struct {
+func (x *AliasAction) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+// UpdateAliases applies all actions at once or none of them, so removing
+// the old index from an alias and adding the new one swaps it with no
+// request seeing neither.
+type UpdateAliasesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Actions       []*AliasAction         `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchRequest) Reset() {
-	*x = SearchRequest{}
-	mi := &file_internal_proto_document_proto_msgTypes[2]
+func (x *UpdateAliasesRequest) Reset() {
+	*x = UpdateAliasesRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchRequest) String() string {
+func (x *UpdateAliasesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest) ProtoMessage() {}
+func (*UpdateAliasesRequest) ProtoMessage() {}
 
-func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_document_proto_msgTypes[2]
+func (x *UpdateAliasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -157,41 +3222,39 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
-func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_internal_proto_document_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use UpdateAliasesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAliasesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *SearchRequest) GetQuery() string {
+func (x *UpdateAliasesRequest) GetActions() []*AliasAction {
 	if x != nil {
-		return x.Query
+		return x.Actions
 	}
-	return ""
+	return nil
 }
 
-type SearchResult struct {
+type UpdateAliasesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResult) Reset() {
-	*x = SearchResult{}
-	mi := &file_internal_proto_document_proto_msgTypes[3]
+func (x *UpdateAliasesResponse) Reset() {
+	*x = UpdateAliasesResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchResult) String() string {
+func (x *UpdateAliasesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResult) ProtoMessage() {}
+func (*UpdateAliasesResponse) ProtoMessage() {}
 
-func (x *SearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_document_proto_msgTypes[3]
+func (x *UpdateAliasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -202,47 +3265,88 @@ func (x *SearchResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
-func (*SearchResult) Descriptor() ([]byte, []int) {
-	return file_internal_proto_document_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use UpdateAliasesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAliasesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *SearchResult) GetId() string {
+// Reindex copies the stored documents of source (an index or alias) into
+// dest, analyzing and embedding them again with dest's settings. It returns
+// at once; poll GetReindexJob for progress.
+type ReindexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Dest          string                 `protobuf:"bytes,2,opt,name=dest,proto3" json:"dest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexRequest) Reset() {
+	*x = ReindexRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexRequest) ProtoMessage() {}
+
+func (x *ReindexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[43]
 	if x != nil {
-		return x.Id
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexRequest.ProtoReflect.Descriptor instead.
+func (*ReindexRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReindexRequest) GetSource() string {
+	if x != nil {
+		return x.Source
 	}
 	return ""
 }
 
-func (x *SearchResult) GetScore() float64 {
+func (x *ReindexRequest) GetDest() string {
 	if x != nil {
-		return x.Score
+		return x.Dest
 	}
-	return 0
+	return ""
 }
 
-type SearchResponse struct {
+type ReindexResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Job           *ReindexJob            `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResponse) Reset() {
-	*x = SearchResponse{}
-	mi := &file_internal_proto_document_proto_msgTypes[4]
+func (x *ReindexResponse) Reset() {
+	*x = ReindexResponse{}
+	mi := &file_internal_proto_document_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchResponse) String() string {
+func (x *ReindexResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResponse) ProtoMessage() {}
+func (*ReindexResponse) ProtoMessage() {}
 
-func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_document_proto_msgTypes[4]
+func (x *ReindexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -253,45 +3357,41 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
-func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_document_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ReindexResponse.ProtoReflect.Descriptor instead.
+func (*ReindexResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *SearchResponse) GetResults() []*SearchResult {
+func (x *ReindexResponse) GetJob() *ReindexJob {
 	if x != nil {
-		return x.Results
+		return x.Job
 	}
 	return nil
 }
 
-type DocumentProto struct {
+// Jobs are kept for an hour after they finish.
+type GetReindexJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Fields        map[string]string      `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Vectors       map[string]*Vector     `protobuf:"bytes,3,rep,name=vectors,proto3" json:"vectors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Metadata      []byte                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DocumentProto) Reset() {
-	*x = DocumentProto{}
-	mi := &file_internal_proto_document_proto_msgTypes[5]
+func (x *GetReindexJobRequest) Reset() {
+	*x = GetReindexJobRequest{}
+	mi := &file_internal_proto_document_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DocumentProto) String() string {
+func (x *GetReindexJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentProto) ProtoMessage() {}
+func (*GetReindexJobRequest) ProtoMessage() {}
 
-func (x *DocumentProto) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_document_proto_msgTypes[5]
+func (x *GetReindexJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -302,115 +3402,317 @@ func (x *DocumentProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentProto.ProtoReflect.Descriptor instead.
-func (*DocumentProto) Descriptor() ([]byte, []int) {
-	return file_internal_proto_document_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetReindexJobRequest.ProtoReflect.Descriptor instead.
+func (*GetReindexJobRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *DocumentProto) GetId() string {
+func (x *GetReindexJobRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *DocumentProto) GetFields() map[string]string {
+type ReindexJob struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Dest   string                 `protobuf:"bytes,3,opt,name=dest,proto3" json:"dest,omitempty"`
+	State  ReindexState           `protobuf:"varint,4,opt,name=state,proto3,enum=zenith.ReindexState" json:"state,omitempty"`
+	Total  int64                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Copied int64                  `protobuf:"varint,6,opt,name=copied,proto3" json:"copied,omitempty"`
+	Failed int64                  `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+	// The first few failures.
+	Errors []string `protobuf:"bytes,8,rep,name=errors,proto3" json:"errors,omitempty"`
+	// Copied documents dest is still embedding; swap aliases once this
+	// reaches zero after the job is done.
+	PendingEmbeddings int64 `protobuf:"varint,9,opt,name=pending_embeddings,json=pendingEmbeddings,proto3" json:"pending_embeddings,omitempty"`
+	StartedUnix       int64 `protobuf:"varint,10,opt,name=started_unix,json=startedUnix,proto3" json:"started_unix,omitempty"`
+	FinishedUnix      int64 `protobuf:"varint,11,opt,name=finished_unix,json=finishedUnix,proto3" json:"finished_unix,omitempty"`
+	// Documents deleted from the source before they were copied; copied,
+	// failed and skipped add up to total once the job is done.
+	Skipped       int64 `protobuf:"varint,12,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexJob) Reset() {
+	*x = ReindexJob{}
+	mi := &file_internal_proto_document_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexJob) ProtoMessage() {}
+
+func (x *ReindexJob) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_document_proto_msgTypes[46]
 	if x != nil {
-		return x.Fields
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *DocumentProto) GetVectors() map[string]*Vector {
+// Deprecated: Use ReindexJob.ProtoReflect.Descriptor instead.
+func (*ReindexJob) Descriptor() ([]byte, []int) {
+	return file_internal_proto_document_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ReindexJob) GetId() string {
 	if x != nil {
-		return x.Vectors
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *DocumentProto) GetMetadata() []byte {
+func (x *ReindexJob) GetSource() string {
 	if x != nil {
-		return x.Metadata
+		return x.Source
 	}
-	return nil
+	return ""
 }
 
-func (x *DocumentProto) GetVersion() int64 {
+func (x *ReindexJob) GetDest() string {
 	if x != nil {
-		return x.Version
+		return x.Dest
 	}
-	return 0
+	return ""
 }
 
-func (x *DocumentProto) GetStatus() string {
+func (x *ReindexJob) GetState() ReindexState {
 	if x != nil {
-		return x.Status
+		return x.State
 	}
-	return ""
+	return ReindexState_REINDEX_RUNNING
 }
 
-type Vector struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Elements      []float32              `protobuf:"fixed32,1,rep,packed,name=elements,proto3" json:"elements,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ReindexJob) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
 }
 
-func (x *Vector) Reset() {
-	*x = Vector{}
-	mi := &file_internal_proto_document_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ReindexJob) GetCopied() int64 {
+	if x != nil {
+		return x.Copied
+	}
+	return 0
 }
 
-func (x *Vector) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ReindexJob) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
 }
 
-func (*Vector) ProtoMessage() {}
+func (x *ReindexJob) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
 
-func (x *Vector) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_document_proto_msgTypes[6]
+func (x *ReindexJob) GetPendingEmbeddings() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PendingEmbeddings
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use Vector.ProtoReflect.Descriptor instead.
-func (*Vector) Descriptor() ([]byte, []int) {
-	return file_internal_proto_document_proto_rawDescGZIP(), []int{6}
+func (x *ReindexJob) GetStartedUnix() int64 {
+	if x != nil {
+		return x.StartedUnix
+	}
+	return 0
 }
 
-func (x *Vector) GetElements() []float32 {
+func (x *ReindexJob) GetFinishedUnix() int64 {
 	if x != nil {
-		return x.Elements
+		return x.FinishedUnix
 	}
-	return nil
+	return 0
+}
+
+func (x *ReindexJob) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
 }
 
 var File_internal_proto_document_proto protoreflect.FileDescriptor
 
 const file_internal_proto_document_proto_rawDesc = "" +
 	"\n" +
-	"\x1dinternal/proto/document.proto\x12\x06zenith\"2\n" +
+	"\x1dinternal/proto/document.proto\x12\x06zenith\"\x8f\x02\n" +
 	"\fIndexRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\tR\x04data\"A\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1e\n" +
+	"\n" +
+	"popularity\x18\x04 \x01(\x01R\n" +
+	"popularity\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x128\n" +
+	"\x06fields\x18\x06 \x03(\v2 .zenith.IndexRequest.FieldsEntryR\x06fields\x12\x14\n" +
+	"\x05index\x18\a \x01(\tR\x05index\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
 	"\rIndexResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\bR\x06status\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"%\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x80\x03\n" +
+	"\rBulkOperation\x12.\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1a.zenith.BulkOperation.TypeR\x04type\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\tR\x05index\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\tR\x04data\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12#\n" +
+	"\n" +
+	"popularity\x18\x06 \x01(\x01H\x00R\n" +
+	"popularity\x88\x01\x01\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\x129\n" +
+	"\x06fields\x18\b \x03(\v2!.zenith.BulkOperation.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\")\n" +
+	"\x04Type\x12\t\n" +
+	"\x05INDEX\x10\x00\x12\n" +
+	"\n" +
+	"\x06UPDATE\x10\x01\x12\n" +
+	"\n" +
+	"\x06DELETE\x10\x02B\r\n" +
+	"\v_popularity\"\\\n" +
+	"\x0eBulkItemResult\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xac\x01\n" +
+	"\x11BulkIndexResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.zenith.BulkItemResultR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tsucceeded\x18\x03 \x01(\x03R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x03R\x06failed\x12\x17\n" +
+	"\atook_ms\x18\x05 \x01(\x03R\x06tookMsJ\x04\b\x06\x10\a\"\x9d\x02\n" +
 	"\rSearchRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\"4\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12&\n" +
+	"\x06fusion\x18\x02 \x01(\v2\x0e.zenith.FusionR\x06fusion\x12\x18\n" +
+	"\aexplain\x18\x03 \x01(\bR\aexplain\x12/\n" +
+	"\thighlight\x18\x04 \x01(\v2\x11.zenith.HighlightR\thighlight\x123\n" +
+	"\vspell_check\x18\x05 \x01(\x0e2\x12.zenith.SpellCheckR\n" +
+	"spellCheck\x12\x1c\n" +
+	"\tfuzziness\x18\x06 \x01(\tR\tfuzziness\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\x12\x14\n" +
+	"\x05index\x18\b \x01(\tR\x05index\"\x97\x01\n" +
+	"\x0eSuggestRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12-\n" +
+	"\x06source\x18\x02 \x01(\x0e2\x15.zenith.SuggestSourceR\x06source\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12\x14\n" +
+	"\x05fuzzy\x18\x04 \x01(\bR\x05fuzzy\x12\x14\n" +
+	"\x05index\x18\x05 \x01(\tR\x05index\"d\n" +
+	"\n" +
+	"Suggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x01R\x06weight\x12\x1a\n" +
+	"\bdistance\x18\x04 \x01(\x05R\bdistance\"G\n" +
+	"\x0fSuggestResponse\x124\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x12.zenith.SuggestionR\vsuggestions\"\x94\x01\n" +
+	"\tHighlight\x12\x17\n" +
+	"\apre_tag\x18\x01 \x01(\tR\x06preTag\x12\x19\n" +
+	"\bpost_tag\x18\x02 \x01(\tR\apostTag\x12#\n" +
+	"\rfragment_size\x18\x03 \x01(\x05R\ffragmentSize\x12.\n" +
+	"\x13number_of_fragments\x18\x04 \x01(\x05R\x11numberOfFragments\"\x81\x02\n" +
+	"\x06Fusion\x12,\n" +
+	"\x06method\x18\x01 \x01(\x0e2\x14.zenith.FusionMethodR\x06method\x12\f\n" +
+	"\x01k\x18\x02 \x01(\x01R\x01k\x12*\n" +
+	"\x0ekeyword_weight\x18\x03 \x01(\x01H\x00R\rkeywordWeight\x88\x01\x01\x12(\n" +
+	"\rvector_weight\x18\x04 \x01(\x01H\x01R\fvectorWeight\x88\x01\x01\x12@\n" +
+	"\rnormalization\x18\x05 \x01(\x0e2\x1a.zenith.ScoreNormalizationR\rnormalizationB\x11\n" +
+	"\x0f_keyword_weightB\x10\n" +
+	"\x0e_vector_weight\"\x8b\x01\n" +
 	"\fSearchResult\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05score\x18\x02 \x01(\x01R\x05score\"@\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x125\n" +
+	"\vexplanation\x18\x03 \x01(\v2\x13.zenith.ExplanationR\vexplanation\x12\x1e\n" +
+	"\n" +
+	"highlights\x18\x04 \x03(\tR\n" +
+	"highlights\"t\n" +
+	"\vExplanation\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x12-\n" +
+	"\adetails\x18\x03 \x03(\v2\x13.zenith.ExplanationR\adetails\"\x90\x01\n" +
+	"\x0eExplainRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12&\n" +
+	"\x06fusion\x18\x03 \x01(\v2\x0e.zenith.FusionR\x06fusion\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05index\x18\x05 \x01(\tR\x05index\"\\\n" +
+	"\x0fExplainResponse\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x125\n" +
+	"\vexplanation\x18\x02 \x01(\v2\x13.zenith.ExplanationR\vexplanation\"\xa8\x02\n" +
 	"\x0eSearchResponse\x12.\n" +
-	"\aresults\x18\x01 \x03(\v2\x14.zenith.SearchResultR\aresults\"\xed\x02\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.zenith.SearchResultR\aresults\x12)\n" +
+	"\x10semantic_skipped\x18\x02 \x01(\bR\x0fsemanticSkipped\x12'\n" +
+	"\x0fdegraded_reason\x18\x03 \x01(\tR\x0edegradedReason\x12\x1e\n" +
+	"\n" +
+	"suggestion\x18\x04 \x01(\tR\n" +
+	"suggestion\x12%\n" +
+	"\x0eauto_corrected\x18\x05 \x01(\bR\rautoCorrected\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12/\n" +
+	"\x13expansion_truncated\x18\a \x01(\bR\x12expansionTruncated\"\x84\x01\n" +
+	"\x0eAnalyzeRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1a\n" +
+	"\banalyzer\x18\x02 \x01(\tR\banalyzer\x12\x14\n" +
+	"\x05field\x18\x03 \x01(\tR\x05field\x12\x16\n" +
+	"\x06search\x18\x04 \x01(\bR\x06search\x12\x14\n" +
+	"\x05index\x18\x05 \x01(\tR\x05index\"<\n" +
+	"\fPhoneticCode\x12\x18\n" +
+	"\aencoder\x18\x01 \x01(\tR\aencoder\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x8b\x02\n" +
+	"\rAnalyzedToken\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\tR\x04term\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\x05R\x03end\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x12\x18\n" +
+	"\akeyword\x18\x06 \x01(\bR\akeyword\x12\x1f\n" +
+	"\vedge_ngrams\x18\a \x03(\tR\n" +
+	"edgeNgrams\x120\n" +
+	"\bphonetic\x18\b \x03(\v2\x14.zenith.PhoneticCodeR\bphonetic\x12!\n" +
+	"\finfix_ngrams\x18\t \x03(\tR\vinfixNgrams\"\\\n" +
+	"\x0fAnalyzeResponse\x12\x1a\n" +
+	"\banalyzer\x18\x01 \x01(\tR\banalyzer\x12-\n" +
+	"\x06tokens\x18\x02 \x03(\v2\x15.zenith.AnalyzedTokenR\x06tokens\".\n" +
+	"\x16EmbeddingStatusRequest\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\tR\x05index\"\x80\x01\n" +
+	"\x10EmbeddingFailure\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\battempts\x18\x02 \x01(\x05R\battempts\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12*\n" +
+	"\x11last_attempt_unix\x18\x04 \x01(\x03R\x0flastAttemptUnix\"x\n" +
+	"\x13EmbeddingCacheStats\x12\x12\n" +
+	"\x04hits\x18\x01 \x01(\x04R\x04hits\x12\x1b\n" +
+	"\tdisk_hits\x18\x02 \x01(\x04R\bdiskHits\x12\x16\n" +
+	"\x06misses\x18\x03 \x01(\x04R\x06misses\x12\x18\n" +
+	"\aentries\x18\x04 \x01(\x03R\aentries\"\xea\x01\n" +
+	"\x17EmbeddingStatusResponse\x12\x18\n" +
+	"\apending\x18\x01 \x01(\x03R\apending\x12\x1c\n" +
+	"\tconverted\x18\x02 \x01(\x03R\tconverted\x12\x16\n" +
+	"\x06queued\x18\x03 \x01(\x03R\x06queued\x124\n" +
+	"\bfailures\x18\x04 \x03(\v2\x18.zenith.EmbeddingFailureR\bfailures\x121\n" +
+	"\x05cache\x18\x05 \x01(\v2\x1b.zenith.EmbeddingCacheStatsR\x05cache\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x03R\x06failed\"\xed\x02\n" +
 	"\rDocumentProto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
 	"\x06fields\x18\x02 \x03(\v2!.zenith.DocumentProto.FieldsEntryR\x06fields\x12<\n" +
@@ -425,10 +3727,141 @@ const file_internal_proto_document_proto_rawDesc = "" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12$\n" +
 	"\x05value\x18\x02 \x01(\v2\x0e.zenith.VectorR\x05value:\x028\x01\"$\n" +
 	"\x06Vector\x12\x1a\n" +
-	"\belements\x18\x01 \x03(\x02R\belements2\x87\x01\n" +
+	"\belements\x18\x01 \x03(\x02R\belements\"\xcb\x02\n" +
+	"\fFieldMapping\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x11.zenith.FieldTypeR\x04type\x12\x1a\n" +
+	"\banalyzer\x18\x03 \x01(\tR\banalyzer\x12'\n" +
+	"\x0fsearch_analyzer\x18\x04 \x01(\tR\x0esearchAnalyzer\x12\x1b\n" +
+	"\x06stored\x18\x05 \x01(\bH\x00R\x06stored\x88\x01\x01\x12\x1d\n" +
+	"\aindexed\x18\x06 \x01(\bH\x01R\aindexed\x88\x01\x01\x12\x12\n" +
+	"\x04dims\x18\a \x01(\x05R\x04dims\x12,\n" +
+	"\x06metric\x18\b \x01(\x0e2\x14.zenith.VectorMetricR\x06metric\x12&\n" +
+	"\x06ngrams\x18\t \x01(\v2\x0e.zenith.NGramsR\x06ngramsB\t\n" +
+	"\a_storedB\n" +
+	"\n" +
+	"\b_indexed\"x\n" +
+	"\x06NGrams\x12\x19\n" +
+	"\bedge_min\x18\x01 \x01(\x05R\aedgeMin\x12\x19\n" +
+	"\bedge_max\x18\x02 \x01(\x05R\aedgeMax\x12\x1b\n" +
+	"\tinfix_min\x18\x03 \x01(\x05R\binfixMin\x12\x1b\n" +
+	"\tinfix_max\x18\x04 \x01(\x05R\binfixMax\"P\n" +
+	"\x06Schema\x12,\n" +
+	"\x06fields\x18\x01 \x03(\v2\x14.zenith.FieldMappingR\x06fields\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"s\n" +
+	"\x12CreateIndexRequest\x12&\n" +
+	"\x06schema\x18\x01 \x01(\v2\x0e.zenith.SchemaR\x06schema\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\tR\x05index\x12\x1f\n" +
+	"\vconfig_json\x18\x03 \x01(\tR\n" +
+	"configJson\"=\n" +
+	"\x13CreateIndexResponse\x12&\n" +
+	"\x06schema\x18\x01 \x01(\v2\x0e.zenith.SchemaR\x06schema\"W\n" +
+	"\x11PutMappingRequest\x12,\n" +
+	"\x06fields\x18\x01 \x03(\v2\x14.zenith.FieldMappingR\x06fields\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\tR\x05index\"<\n" +
+	"\x12PutMappingResponse\x12&\n" +
+	"\x06schema\x18\x01 \x01(\v2\x0e.zenith.SchemaR\x06schema\")\n" +
+	"\x11GetMappingRequest\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\tR\x05index\"<\n" +
+	"\x12GetMappingResponse\x12&\n" +
+	"\x06schema\x18\x01 \x01(\v2\x0e.zenith.SchemaR\x06schema\"*\n" +
+	"\x12DeleteIndexRequest\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\tR\x05index\"\x15\n" +
+	"\x13DeleteIndexResponse\"\x14\n" +
+	"\x12ListIndexesRequest\"\x98\x01\n" +
+	"\tIndexInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tdocuments\x18\x02 \x01(\x03R\tdocuments\x12\x18\n" +
+	"\apending\x18\x03 \x01(\x03R\apending\x12%\n" +
+	"\x0eschema_version\x18\x04 \x01(\x03R\rschemaVersion\x12\x18\n" +
+	"\aaliases\x18\x05 \x03(\tR\aaliases\"B\n" +
+	"\x13ListIndexesResponse\x12+\n" +
+	"\aindexes\x18\x01 \x03(\v2\x11.zenith.IndexInfoR\aindexes\"\x84\x01\n" +
+	"\vAliasAction\x12,\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x18.zenith.AliasAction.TypeR\x04type\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\tR\x05index\"\x1b\n" +
+	"\x04Type\x12\a\n" +
+	"\x03ADD\x10\x00\x12\n" +
+	"\n" +
+	"\x06REMOVE\x10\x01\"E\n" +
+	"\x14UpdateAliasesRequest\x12-\n" +
+	"\aactions\x18\x01 \x03(\v2\x13.zenith.AliasActionR\aactions\"\x17\n" +
+	"\x15UpdateAliasesResponse\"<\n" +
+	"\x0eReindexRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x12\n" +
+	"\x04dest\x18\x02 \x01(\tR\x04dest\"7\n" +
+	"\x0fReindexResponse\x12$\n" +
+	"\x03job\x18\x01 \x01(\v2\x12.zenith.ReindexJobR\x03job\"&\n" +
+	"\x14GetReindexJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe3\x02\n" +
+	"\n" +
+	"ReindexJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x12\n" +
+	"\x04dest\x18\x03 \x01(\tR\x04dest\x12*\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x14.zenith.ReindexStateR\x05state\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x03R\x05total\x12\x16\n" +
+	"\x06copied\x18\x06 \x01(\x03R\x06copied\x12\x16\n" +
+	"\x06failed\x18\a \x01(\x03R\x06failed\x12\x16\n" +
+	"\x06errors\x18\b \x03(\tR\x06errors\x12-\n" +
+	"\x12pending_embeddings\x18\t \x01(\x03R\x11pendingEmbeddings\x12!\n" +
+	"\fstarted_unix\x18\n" +
+	" \x01(\x03R\vstartedUnix\x12#\n" +
+	"\rfinished_unix\x18\v \x01(\x03R\ffinishedUnix\x12\x18\n" +
+	"\askipped\x18\f \x01(\x03R\askipped*X\n" +
+	"\n" +
+	"SpellCheck\x12\x13\n" +
+	"\x0fSPELL_CHECK_OFF\x10\x00\x12\x17\n" +
+	"\x13SPELL_CHECK_SUGGEST\x10\x01\x12\x1c\n" +
+	"\x18SPELL_CHECK_AUTO_CORRECT\x10\x02*6\n" +
+	"\rSuggestSource\x12\x11\n" +
+	"\rSUGGEST_TERMS\x10\x00\x12\x12\n" +
+	"\x0eSUGGEST_TITLES\x10\x01*V\n" +
+	"\fFusionMethod\x12\x12\n" +
+	"\x0eFUSION_DEFAULT\x10\x00\x12\x0e\n" +
+	"\n" +
+	"FUSION_RRF\x10\x01\x12\x11\n" +
+	"\rFUSION_CONVEX\x10\x02\x12\x0f\n" +
+	"\vFUSION_DBSF\x10\x03*e\n" +
+	"\x12ScoreNormalization\x12\x19\n" +
+	"\x15NORMALIZATION_DEFAULT\x10\x00\x12\x19\n" +
+	"\x15NORMALIZATION_MIN_MAX\x10\x01\x12\x19\n" +
+	"\x15NORMALIZATION_Z_SCORE\x10\x02*n\n" +
+	"\tFieldType\x12\x1a\n" +
+	"\x16FIELD_TYPE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"FIELD_TEXT\x10\x01\x12\x11\n" +
+	"\rFIELD_KEYWORD\x10\x02\x12\x10\n" +
+	"\fFIELD_NUMBER\x10\x03\x12\x10\n" +
+	"\fFIELD_VECTOR\x10\x04*`\n" +
+	"\fVectorMetric\x12\x16\n" +
+	"\x12METRIC_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rMETRIC_COSINE\x10\x01\x12\x16\n" +
+	"\x12METRIC_DOT_PRODUCT\x10\x02\x12\r\n" +
+	"\tMETRIC_L2\x10\x03*K\n" +
+	"\fReindexState\x12\x13\n" +
+	"\x0fREINDEX_RUNNING\x10\x00\x12\x10\n" +
+	"\fREINDEX_DONE\x10\x01\x12\x14\n" +
+	"\x10REINDEX_CANCELED\x10\x022\xff\a\n" +
 	"\rSearchService\x12=\n" +
-	"\x0eIndexDocuments\x12\x14.zenith.IndexRequest\x1a\x15.zenith.IndexResponse\x127\n" +
-	"\x06Search\x12\x15.zenith.SearchRequest\x1a\x16.zenith.SearchResponseB\x14Z\x12gen/go/zenithprotob\x06proto3"
+	"\x0eIndexDocuments\x12\x14.zenith.IndexRequest\x1a\x15.zenith.IndexResponse\x12?\n" +
+	"\tBulkIndex\x12\x15.zenith.BulkOperation\x1a\x19.zenith.BulkIndexResponse(\x01\x127\n" +
+	"\x06Search\x12\x15.zenith.SearchRequest\x1a\x16.zenith.SearchResponse\x12R\n" +
+	"\x0fEmbeddingStatus\x12\x1e.zenith.EmbeddingStatusRequest\x1a\x1f.zenith.EmbeddingStatusResponse\x12:\n" +
+	"\aExplain\x12\x16.zenith.ExplainRequest\x1a\x17.zenith.ExplainResponse\x12:\n" +
+	"\aSuggest\x12\x16.zenith.SuggestRequest\x1a\x17.zenith.SuggestResponse\x12:\n" +
+	"\aAnalyze\x12\x16.zenith.AnalyzeRequest\x1a\x17.zenith.AnalyzeResponse\x12F\n" +
+	"\vCreateIndex\x12\x1a.zenith.CreateIndexRequest\x1a\x1b.zenith.CreateIndexResponse\x12C\n" +
+	"\n" +
+	"PutMapping\x12\x19.zenith.PutMappingRequest\x1a\x1a.zenith.PutMappingResponse\x12C\n" +
+	"\n" +
+	"GetMapping\x12\x19.zenith.GetMappingRequest\x1a\x1a.zenith.GetMappingResponse\x12F\n" +
+	"\vDeleteIndex\x12\x1a.zenith.DeleteIndexRequest\x1a\x1b.zenith.DeleteIndexResponse\x12F\n" +
+	"\vListIndexes\x12\x1a.zenith.ListIndexesRequest\x1a\x1b.zenith.ListIndexesResponse\x12L\n" +
+	"\rUpdateAliases\x12\x1c.zenith.UpdateAliasesRequest\x1a\x1d.zenith.UpdateAliasesResponse\x12:\n" +
+	"\aReindex\x12\x16.zenith.ReindexRequest\x1a\x17.zenith.ReindexResponse\x12A\n" +
+	"\rGetReindexJob\x12\x1c.zenith.GetReindexJobRequest\x1a\x12.zenith.ReindexJobB\x14Z\x12gen/go/zenithprotob\x06proto3"
 
 var (
 	file_internal_proto_document_proto_rawDescOnce sync.Once
@@ -442,32 +3875,143 @@ func file_internal_proto_document_proto_rawDescGZIP() []byte {
 	return file_internal_proto_document_proto_rawDescData
 }
 
-var file_internal_proto_document_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_internal_proto_document_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_internal_proto_document_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_internal_proto_document_proto_goTypes = []any{
-	(*IndexRequest)(nil),   // 0: zenith.IndexRequest
-	(*IndexResponse)(nil),  // 1: zenith.IndexResponse
-	(*SearchRequest)(nil),  // 2: zenith.SearchRequest
-	(*SearchResult)(nil),   // 3: zenith.SearchResult
-	(*SearchResponse)(nil), // 4: zenith.SearchResponse
-	(*DocumentProto)(nil),  // 5: zenith.DocumentProto
-	(*Vector)(nil),         // 6: zenith.Vector
-	nil,                    // 7: zenith.DocumentProto.FieldsEntry
-	nil,                    // 8: zenith.DocumentProto.VectorsEntry
+	(SpellCheck)(0),                 // 0: zenith.SpellCheck
+	(SuggestSource)(0),              // 1: zenith.SuggestSource
+	(FusionMethod)(0),               // 2: zenith.FusionMethod
+	(ScoreNormalization)(0),         // 3: zenith.ScoreNormalization
+	(FieldType)(0),                  // 4: zenith.FieldType
+	(VectorMetric)(0),               // 5: zenith.VectorMetric
+	(ReindexState)(0),               // 6: zenith.ReindexState
+	(BulkOperation_Type)(0),         // 7: zenith.BulkOperation.Type
+	(AliasAction_Type)(0),           // 8: zenith.AliasAction.Type
+	(*IndexRequest)(nil),            // 9: zenith.IndexRequest
+	(*IndexResponse)(nil),           // 10: zenith.IndexResponse
+	(*BulkOperation)(nil),           // 11: zenith.BulkOperation
+	(*BulkItemResult)(nil),          // 12: zenith.BulkItemResult
+	(*BulkIndexResponse)(nil),       // 13: zenith.BulkIndexResponse
+	(*SearchRequest)(nil),           // 14: zenith.SearchRequest
+	(*SuggestRequest)(nil),          // 15: zenith.SuggestRequest
+	(*Suggestion)(nil),              // 16: zenith.Suggestion
+	(*SuggestResponse)(nil),         // 17: zenith.SuggestResponse
+	(*Highlight)(nil),               // 18: zenith.Highlight
+	(*Fusion)(nil),                  // 19: zenith.Fusion
+	(*SearchResult)(nil),            // 20: zenith.SearchResult
+	(*Explanation)(nil),             // 21: zenith.Explanation
+	(*ExplainRequest)(nil),          // 22: zenith.ExplainRequest
+	(*ExplainResponse)(nil),         // 23: zenith.ExplainResponse
+	(*SearchResponse)(nil),          // 24: zenith.SearchResponse
+	(*AnalyzeRequest)(nil),          // 25: zenith.AnalyzeRequest
+	(*PhoneticCode)(nil),            // 26: zenith.PhoneticCode
+	(*AnalyzedToken)(nil),           // 27: zenith.AnalyzedToken
+	(*AnalyzeResponse)(nil),         // 28: zenith.AnalyzeResponse
+	(*EmbeddingStatusRequest)(nil),  // 29: zenith.EmbeddingStatusRequest
+	(*EmbeddingFailure)(nil),        // 30: zenith.EmbeddingFailure
+	(*EmbeddingCacheStats)(nil),     // 31: zenith.EmbeddingCacheStats
+	(*EmbeddingStatusResponse)(nil), // 32: zenith.EmbeddingStatusResponse
+	(*DocumentProto)(nil),           // 33: zenith.DocumentProto
+	(*Vector)(nil),                  // 34: zenith.Vector
+	(*FieldMapping)(nil),            // 35: zenith.FieldMapping
+	(*NGrams)(nil),                  // 36: zenith.NGrams
+	(*Schema)(nil),                  // 37: zenith.Schema
+	(*CreateIndexRequest)(nil),      // 38: zenith.CreateIndexRequest
+	(*CreateIndexResponse)(nil),     // 39: zenith.CreateIndexResponse
+	(*PutMappingRequest)(nil),       // 40: zenith.PutMappingRequest
+	(*PutMappingResponse)(nil),      // 41: zenith.PutMappingResponse
+	(*GetMappingRequest)(nil),       // 42: zenith.GetMappingRequest
+	(*GetMappingResponse)(nil),      // 43: zenith.GetMappingResponse
+	(*DeleteIndexRequest)(nil),      // 44: zenith.DeleteIndexRequest
+	(*DeleteIndexResponse)(nil),     // 45: zenith.DeleteIndexResponse
+	(*ListIndexesRequest)(nil),      // 46: zenith.ListIndexesRequest
+	(*IndexInfo)(nil),               // 47: zenith.IndexInfo
+	(*ListIndexesResponse)(nil),     // 48: zenith.ListIndexesResponse
+	(*AliasAction)(nil),             // 49: zenith.AliasAction
+	(*UpdateAliasesRequest)(nil),    // 50: zenith.UpdateAliasesRequest
+	(*UpdateAliasesResponse)(nil),   // 51: zenith.UpdateAliasesResponse
+	(*ReindexRequest)(nil),          // 52: zenith.ReindexRequest
+	(*ReindexResponse)(nil),         // 53: zenith.ReindexResponse
+	(*GetReindexJobRequest)(nil),    // 54: zenith.GetReindexJobRequest
+	(*ReindexJob)(nil),              // 55: zenith.ReindexJob
+	nil,                             // 56: zenith.IndexRequest.FieldsEntry
+	nil,                             // 57: zenith.BulkOperation.FieldsEntry
+	nil,                             // 58: zenith.DocumentProto.FieldsEntry
+	nil,                             // 59: zenith.DocumentProto.VectorsEntry
 }
 var file_internal_proto_document_proto_depIdxs = []int32{
-	3, // 0: zenith.SearchResponse.results:type_name -> zenith.SearchResult
-	7, // 1: zenith.DocumentProto.fields:type_name -> zenith.DocumentProto.FieldsEntry
-	8, // 2: zenith.DocumentProto.vectors:type_name -> zenith.DocumentProto.VectorsEntry
-	6, // 3: zenith.DocumentProto.VectorsEntry.value:type_name -> zenith.Vector
-	0, // 4: zenith.SearchService.IndexDocuments:input_type -> zenith.IndexRequest
-	2, // 5: zenith.SearchService.Search:input_type -> zenith.SearchRequest
-	1, // 6: zenith.SearchService.IndexDocuments:output_type -> zenith.IndexResponse
-	4, // 7: zenith.SearchService.Search:output_type -> zenith.SearchResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	56, // 0: zenith.IndexRequest.fields:type_name -> zenith.IndexRequest.FieldsEntry
+	7,  // 1: zenith.BulkOperation.type:type_name -> zenith.BulkOperation.Type
+	57, // 2: zenith.BulkOperation.fields:type_name -> zenith.BulkOperation.FieldsEntry
+	12, // 3: zenith.BulkIndexResponse.items:type_name -> zenith.BulkItemResult
+	19, // 4: zenith.SearchRequest.fusion:type_name -> zenith.Fusion
+	18, // 5: zenith.SearchRequest.highlight:type_name -> zenith.Highlight
+	0,  // 6: zenith.SearchRequest.spell_check:type_name -> zenith.SpellCheck
+	1,  // 7: zenith.SuggestRequest.source:type_name -> zenith.SuggestSource
+	16, // 8: zenith.SuggestResponse.suggestions:type_name -> zenith.Suggestion
+	2,  // 9: zenith.Fusion.method:type_name -> zenith.FusionMethod
+	3,  // 10: zenith.Fusion.normalization:type_name -> zenith.ScoreNormalization
+	21, // 11: zenith.SearchResult.explanation:type_name -> zenith.Explanation
+	21, // 12: zenith.Explanation.details:type_name -> zenith.Explanation
+	19, // 13: zenith.ExplainRequest.fusion:type_name -> zenith.Fusion
+	21, // 14: zenith.ExplainResponse.explanation:type_name -> zenith.Explanation
+	20, // 15: zenith.SearchResponse.results:type_name -> zenith.SearchResult
+	26, // 16: zenith.AnalyzedToken.phonetic:type_name -> zenith.PhoneticCode
+	27, // 17: zenith.AnalyzeResponse.tokens:type_name -> zenith.AnalyzedToken
+	30, // 18: zenith.EmbeddingStatusResponse.failures:type_name -> zenith.EmbeddingFailure
+	31, // 19: zenith.EmbeddingStatusResponse.cache:type_name -> zenith.EmbeddingCacheStats
+	58, // 20: zenith.DocumentProto.fields:type_name -> zenith.DocumentProto.FieldsEntry
+	59, // 21: zenith.DocumentProto.vectors:type_name -> zenith.DocumentProto.VectorsEntry
+	4,  // 22: zenith.FieldMapping.type:type_name -> zenith.FieldType
+	5,  // 23: zenith.FieldMapping.metric:type_name -> zenith.VectorMetric
+	36, // 24: zenith.FieldMapping.ngrams:type_name -> zenith.NGrams
+	35, // 25: zenith.Schema.fields:type_name -> zenith.FieldMapping
+	37, // 26: zenith.CreateIndexRequest.schema:type_name -> zenith.Schema
+	37, // 27: zenith.CreateIndexResponse.schema:type_name -> zenith.Schema
+	35, // 28: zenith.PutMappingRequest.fields:type_name -> zenith.FieldMapping
+	37, // 29: zenith.PutMappingResponse.schema:type_name -> zenith.Schema
+	37, // 30: zenith.GetMappingResponse.schema:type_name -> zenith.Schema
+	47, // 31: zenith.ListIndexesResponse.indexes:type_name -> zenith.IndexInfo
+	8,  // 32: zenith.AliasAction.type:type_name -> zenith.AliasAction.Type
+	49, // 33: zenith.UpdateAliasesRequest.actions:type_name -> zenith.AliasAction
+	55, // 34: zenith.ReindexResponse.job:type_name -> zenith.ReindexJob
+	6,  // 35: zenith.ReindexJob.state:type_name -> zenith.ReindexState
+	34, // 36: zenith.DocumentProto.VectorsEntry.value:type_name -> zenith.Vector
+	9,  // 37: zenith.SearchService.IndexDocuments:input_type -> zenith.IndexRequest
+	11, // 38: zenith.SearchService.BulkIndex:input_type -> zenith.BulkOperation
+	14, // 39: zenith.SearchService.Search:input_type -> zenith.SearchRequest
+	29, // 40: zenith.SearchService.EmbeddingStatus:input_type -> zenith.EmbeddingStatusRequest
+	22, // 41: zenith.SearchService.Explain:input_type -> zenith.ExplainRequest
+	15, // 42: zenith.SearchService.Suggest:input_type -> zenith.SuggestRequest
+	25, // 43: zenith.SearchService.Analyze:input_type -> zenith.AnalyzeRequest
+	38, // 44: zenith.SearchService.CreateIndex:input_type -> zenith.CreateIndexRequest
+	40, // 45: zenith.SearchService.PutMapping:input_type -> zenith.PutMappingRequest
+	42, // 46: zenith.SearchService.GetMapping:input_type -> zenith.GetMappingRequest
+	44, // 47: zenith.SearchService.DeleteIndex:input_type -> zenith.DeleteIndexRequest
+	46, // 48: zenith.SearchService.ListIndexes:input_type -> zenith.ListIndexesRequest
+	50, // 49: zenith.SearchService.UpdateAliases:input_type -> zenith.UpdateAliasesRequest
+	52, // 50: zenith.SearchService.Reindex:input_type -> zenith.ReindexRequest
+	54, // 51: zenith.SearchService.GetReindexJob:input_type -> zenith.GetReindexJobRequest
+	10, // 52: zenith.SearchService.IndexDocuments:output_type -> zenith.IndexResponse
+	13, // 53: zenith.SearchService.BulkIndex:output_type -> zenith.BulkIndexResponse
+	24, // 54: zenith.SearchService.Search:output_type -> zenith.SearchResponse
+	32, // 55: zenith.SearchService.EmbeddingStatus:output_type -> zenith.EmbeddingStatusResponse
+	23, // 56: zenith.SearchService.Explain:output_type -> zenith.ExplainResponse
+	17, // 57: zenith.SearchService.Suggest:output_type -> zenith.SuggestResponse
+	28, // 58: zenith.SearchService.Analyze:output_type -> zenith.AnalyzeResponse
+	39, // 59: zenith.SearchService.CreateIndex:output_type -> zenith.CreateIndexResponse
+	41, // 60: zenith.SearchService.PutMapping:output_type -> zenith.PutMappingResponse
+	43, // 61: zenith.SearchService.GetMapping:output_type -> zenith.GetMappingResponse
+	45, // 62: zenith.SearchService.DeleteIndex:output_type -> zenith.DeleteIndexResponse
+	48, // 63: zenith.SearchService.ListIndexes:output_type -> zenith.ListIndexesResponse
+	51, // 64: zenith.SearchService.UpdateAliases:output_type -> zenith.UpdateAliasesResponse
+	53, // 65: zenith.SearchService.Reindex:output_type -> zenith.ReindexResponse
+	55, // 66: zenith.SearchService.GetReindexJob:output_type -> zenith.ReindexJob
+	52, // [52:67] is the sub-list for method output_type
+	37, // [37:52] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_document_proto_init() }
@@ -475,18 +4019,22 @@ func file_internal_proto_document_proto_init() {
 	if File_internal_proto_document_proto != nil {
 		return
 	}
+	file_internal_proto_document_proto_msgTypes[2].OneofWrappers = []any{}
+	file_internal_proto_document_proto_msgTypes[10].OneofWrappers = []any{}
+	file_internal_proto_document_proto_msgTypes[26].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_document_proto_rawDesc), len(file_internal_proto_document_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      9,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_internal_proto_document_proto_goTypes,
 		DependencyIndexes: file_internal_proto_document_proto_depIdxs,
+		EnumInfos:         file_internal_proto_document_proto_enumTypes,
 		MessageInfos:      file_internal_proto_document_proto_msgTypes,
 	}.Build()
 	File_internal_proto_document_proto = out.File
@@ -0,0 +1,137 @@
+package analysis
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CharFilter rewrites raw text before it is tokenized. Token offsets point
+// into the filtered text, so a filter must keep every byte where it is:
+// replace what it removes with spaces rather than cutting it out.
+type CharFilter interface {
+	Filter(text string) string
+}
+
+// Analyzer turns text into indexable tokens: char filters, then a tokenizer,
+// then token filters, each stage fed the output of the one before.
+type Analyzer struct {
+	Name        string
+	CharFilters []CharFilter
+	Tokenizer   Segmenter
+	Filters     []TokenFilter
+}
+
+// Analyze never returns a term with a control character in it: the index
+// namespaces its keys with them.
+func (a *Analyzer) Analyze(text string) []Token {
+	text = blankControls(text)
+	for _, f := range a.CharFilters {
+		text = f.Filter(text)
+	}
+	tokens := a.Tokenizer.Segment(text)
+	for _, f := range a.Filters {
+		tokens = f.Filter(tokens)
+	}
+	// Filters may bring in text of their own, such as synonyms.
+	return slices.DeleteFunc(tokens, func(t Token) bool {
+		return strings.ContainsFunc(t.Term, unicode.IsControl)
+	})
+}
+
+// blankControls replaces control characters, tabs and newlines included,
+// with spaces of the same byte length, keeping offsets.
+func blankControls(text string) string {
+	if !strings.ContainsFunc(text, unicode.IsControl) {
+		return text
+	}
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if unicode.IsControl(r) {
+			b.WriteString(strings.Repeat(" ", size))
+		} else {
+			b.WriteString(text[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+func (a *Analyzer) Tokenize(text string) []string {
+	tokens := a.Analyze(text)
+
+	terms := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		terms = append(terms, t.Term)
+	}
+	return terms
+}
+
+var (
+	htmlTag    = regexp.MustCompile(`(?s)<!--.*?-->|<[a-zA-Z/!][^>]*>`)
+	htmlEntity = regexp.MustCompile(`&(?:[a-zA-Z]+|#[0-9]+|#x[0-9a-fA-F]+);`)
+)
+
+var htmlEntities = map[string]string{
+	"&amp;": "&", "&lt;": "<", "&gt;": ">", "&quot;": `"`, "&apos;": "'", "&nbsp;": " ",
+}
+
+// HTMLStripCharFilter blanks out markup and decodes the common entities,
+// padding with spaces so offsets still point into the original HTML.
+var HTMLStripCharFilter CharFilter = htmlStrip{}
+
+type htmlStrip struct{}
+
+func (htmlStrip) Filter(text string) string {
+	text = htmlTag.ReplaceAllStringFunc(text, func(tag string) string {
+		return strings.Repeat(" ", len(tag))
+	})
+	return htmlEntity.ReplaceAllStringFunc(text, func(entity string) string {
+		repl, ok := htmlEntities[strings.ToLower(entity)]
+		if !ok {
+			return strings.Repeat(" ", len(entity))
+		}
+		return repl + strings.Repeat(" ", len(entity)-len(repl))
+	})
+}
+
+// WhitespaceSegmenter splits on white space only and keeps everything else,
+// punctuation included.
+type WhitespaceSegmenter struct{}
+
+func (WhitespaceSegmenter) Segment(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				tokens = append(tokens, Token{Term: text[start:i], Start: start, End: i, Position: len(tokens)})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Term: text[start:], Start: start, End: len(text), Position: len(tokens)})
+	}
+	return tokens
+}
+
+// KeywordSegmenter emits the whole text, trimmed, as one token.
+type KeywordSegmenter struct{}
+
+func (KeywordSegmenter) Segment(text string) []Token {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return nil
+	}
+	start := strings.Index(text, trimmed)
+	return []Token{{Term: trimmed, Start: start, End: start + len(trimmed)}}
+}
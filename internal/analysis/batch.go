@@ -0,0 +1,237 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// BatchEmbedder embeds several texts in one round-trip. Results are positional
+// and a failed item carries its own error without failing the whole batch; the
+// returned error is reserved for failures that affect every item.
+type BatchEmbedder interface {
+	Embedder
+	EmbedBatch(ctx context.Context, texts []string) ([]BatchResult, error)
+}
+
+type BatchResult struct {
+	Embedding []float32
+	Err       error
+}
+
+var ErrEmbedderClosed = errors.New("embedder closed")
+
+// EmbedAll embeds texts with a single batch call when e supports it and falls
+// back to one Embed call per text otherwise.
+func EmbedAll(ctx context.Context, e Embedder, texts []string) []BatchResult {
+	if len(texts) == 0 {
+		return nil
+	}
+
+	if be, ok := e.(BatchEmbedder); ok {
+		results, err := be.EmbedBatch(ctx, texts)
+		if err == nil && len(results) == len(texts) {
+			return results
+		}
+		if err == nil {
+			err = errors.New("batch embedder returned wrong number of results")
+		}
+		results = make([]BatchResult, len(texts))
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+
+	results := make([]BatchResult, len(texts))
+	for i, text := range texts {
+		results[i].Embedding, results[i].Err = e.Embed(ctx, text)
+	}
+	return results
+}
+
+type BatchConfig struct {
+	MaxBatchSize int           // flush as soon as this many texts are queued, defaults to 32
+	MaxWait      time.Duration // flush a partial batch after this long, defaults to 5ms
+}
+
+type batchItem struct {
+	ctx    context.Context
+	text   string
+	result chan BatchResult
+}
+
+// BatchingEmbedder fans in Embed calls from concurrent callers and forwards
+// them to the backend in batches of up to MaxBatchSize texts, waiting at most
+// MaxWait for a batch to fill up.
+type BatchingEmbedder struct {
+	backend BatchEmbedder
+	cfg     BatchConfig
+
+	queue  chan batchItem
+	done   chan struct{}
+	mu     sync.RWMutex // guards closed against in-flight submits
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func NewBatchingEmbedder(backend BatchEmbedder, cfg BatchConfig) *BatchingEmbedder {
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = 32
+	}
+	if cfg.MaxWait <= 0 {
+		cfg.MaxWait = 5 * time.Millisecond
+	}
+
+	b := &BatchingEmbedder{
+		backend: backend,
+		cfg:     cfg,
+		queue:   make(chan batchItem, cfg.MaxBatchSize*4),
+		done:    make(chan struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.loop()
+
+	return b
+}
+
+func (b *BatchingEmbedder) ModelID() string {
+	return b.backend.ModelID()
+}
+
+func (b *BatchingEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	res := b.submit(ctx, []string{text})[0]
+	return res.Embedding, res.Err
+}
+
+func (b *BatchingEmbedder) EmbedBatch(ctx context.Context, texts []string) ([]BatchResult, error) {
+	return b.submit(ctx, texts), nil
+}
+
+// Close stops the dispatcher. Queued texts are flushed before it returns.
+func (b *BatchingEmbedder) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.done)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func (b *BatchingEmbedder) submit(ctx context.Context, texts []string) []BatchResult {
+	items := make([]batchItem, len(texts))
+	results := make([]BatchResult, len(texts))
+
+	b.mu.RLock()
+	for i, text := range texts {
+		items[i] = batchItem{ctx: ctx, text: text, result: make(chan BatchResult, 1)}
+
+		if b.closed {
+			items[i].result <- BatchResult{Err: ErrEmbedderClosed}
+			continue
+		}
+
+		select {
+		case b.queue <- items[i]:
+		case <-ctx.Done():
+			items[i].result <- BatchResult{Err: ctx.Err()}
+		}
+	}
+	b.mu.RUnlock()
+
+	for i, item := range items {
+		select {
+		case results[i] = <-item.result:
+		case <-ctx.Done():
+			results[i] = BatchResult{Err: ctx.Err()}
+		}
+	}
+
+	return results
+}
+
+func (b *BatchingEmbedder) loop() {
+	defer b.wg.Done()
+
+	var pending []batchItem
+	var timer *time.Timer
+	var timeout <-chan time.Time
+
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if len(pending) == 0 {
+			return
+		}
+		batch := pending
+		pending = nil
+
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			b.dispatch(batch)
+		}()
+	}
+
+	for {
+		select {
+		case item := <-b.queue:
+			pending = append(pending, item)
+			if len(pending) >= b.cfg.MaxBatchSize {
+				flush()
+			} else if timer == nil {
+				timer = time.NewTimer(b.cfg.MaxWait)
+				timeout = timer.C
+			}
+		case <-timeout:
+			timer, timeout = nil, nil
+			flush()
+		case <-b.done:
+			for {
+				select {
+				case item := <-b.queue:
+					pending = append(pending, item)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b *BatchingEmbedder) dispatch(batch []batchItem) {
+	// Callers that already gave up are dropped, identical texts are sent once.
+	positions := make(map[string]int)
+	var texts []string
+	var live []batchItem
+
+	for _, item := range batch {
+		if err := item.ctx.Err(); err != nil {
+			item.result <- BatchResult{Err: err}
+			continue
+		}
+		live = append(live, item)
+		if _, ok := positions[item.text]; !ok {
+			positions[item.text] = len(texts)
+			texts = append(texts, item.text)
+		}
+	}
+	if len(texts) == 0 {
+		return
+	}
+
+	// The batch outlives any single caller, so it is bounded by the backend's
+	// own timeout rather than by one of the callers' contexts.
+	results := EmbedAll(context.Background(), b.backend, texts)
+
+	for _, item := range live {
+		item.result <- results[positions[item.text]]
+	}
+}
@@ -0,0 +1,161 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+)
+
+// bmRule rewrites a spelling into one or more sound alternatives. Rules are
+// tried in order at every position, so longer patterns are listed first.
+type bmRule struct {
+	pattern string
+	sounds  []string
+	before  string // if set, only applies when followed by one of these letters
+}
+
+// The phonetic alphabet below is lower case for plain sounds plus
+// S (sh), Z (zh), C (ch), c (ts) and x (kh).
+var bmRules = []bmRule{
+	{pattern: "schtsch", sounds: []string{"SC"}},
+	{pattern: "tsch", sounds: []string{"C"}},
+	{pattern: "shch", sounds: []string{"SC"}},
+	{pattern: "zsch", sounds: []string{"C"}},
+	{pattern: "sch", sounds: []string{"S", "sk"}},
+	{pattern: "tch", sounds: []string{"C"}},
+	{pattern: "dzh", sounds: []string{"Z"}},
+	{pattern: "sh", sounds: []string{"S"}},
+	{pattern: "sz", sounds: []string{"S", "s"}},
+	{pattern: "zs", sounds: []string{"Z"}},
+	{pattern: "cz", sounds: []string{"C"}},
+	{pattern: "cs", sounds: []string{"C"}},
+	{pattern: "ch", sounds: []string{"x", "C", "k"}},
+	{pattern: "ck", sounds: []string{"k"}},
+	{pattern: "ph", sounds: []string{"f"}},
+	{pattern: "th", sounds: []string{"t"}},
+	{pattern: "dt", sounds: []string{"t"}},
+	{pattern: "tz", sounds: []string{"c"}},
+	{pattern: "ts", sounds: []string{"c"}},
+	{pattern: "zh", sounds: []string{"Z"}},
+	{pattern: "kh", sounds: []string{"x"}},
+	{pattern: "gh", sounds: []string{"g", ""}},
+	{pattern: "qu", sounds: []string{"kv", "k"}},
+	{pattern: "gu", sounds: []string{"g", "gv"}, before: "aeioy"},
+	{pattern: "rz", sounds: []string{"rz", "Z"}},
+	{pattern: "wr", sounds: []string{"r", "vr"}},
+	{pattern: "c", sounds: []string{"c", "s"}, before: "eiy"},
+	{pattern: "c", sounds: []string{"k"}},
+	{pattern: "g", sounds: []string{"g", "Z"}, before: "eiy"},
+	{pattern: "j", sounds: []string{"Z", "y"}},
+	{pattern: "w", sounds: []string{"v"}},
+	{pattern: "x", sounds: []string{"ks"}},
+	{pattern: "q", sounds: []string{"k"}},
+	{pattern: "z", sounds: []string{"z", "c"}},
+	{pattern: "h", sounds: []string{""}},
+}
+
+// bmApprox folds the sounds into the coarse classes used for matching:
+// sibilants, dentals, labials and velars each collapse to one letter.
+var bmApprox = map[rune]rune{
+	's': 's', 'z': 's', 'S': 's', 'Z': 's', 'c': 's', 'C': 's',
+	't': 't', 'd': 't',
+	'p': 'p', 'b': 'p',
+	'f': 'f', 'v': 'f',
+	'k': 'k', 'g': 'k', 'x': 'k',
+	'l': 'l', 'r': 'r', 'm': 'm', 'n': 'n',
+}
+
+const bmMaxVariants = 8
+
+type beiderMorseEncoder struct{}
+
+func (beiderMorseEncoder) Name() string { return "beider_morse" }
+
+// Encode is a compact take on Beider-Morse Phonetic Matching in its generic,
+// approximate mode: ambiguous spellings (ch, j, sz, ...) branch into every
+// pronunciation they have across European languages, then sounds are folded
+// into broad classes and vowels after the first letter are dropped. It does
+// not carry BMPM's per-language rule tables or language detection.
+func (beiderMorseEncoder) Encode(term string) []string {
+	word := strings.ToLower(FoldDiacritics(term))
+
+	variants := []string{""}
+	for i := 0; i < len(word); {
+		c := word[i]
+		if c < 'a' || c > 'z' {
+			i++
+			continue
+		}
+		if i > 0 && word[i-1] == c && c != 'c' {
+			// Doubled letters sound once.
+			i++
+			continue
+		}
+
+		sounds, n := bmMatch(word, i)
+		var next []string
+		for _, v := range variants {
+			for _, s := range sounds {
+				if len(next) == bmMaxVariants {
+					break
+				}
+				next = append(next, v+s)
+			}
+		}
+		variants = next
+		i += n
+	}
+
+	seen := make(map[string]bool)
+	var codes []string
+	for _, v := range variants {
+		code := bmFold(v)
+		if code != "" && !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+func bmMatch(word string, i int) ([]string, int) {
+	for _, r := range bmRules {
+		if !strings.HasPrefix(word[i:], r.pattern) {
+			continue
+		}
+		if r.before != "" {
+			after := i + len(r.pattern)
+			if after >= len(word) || strings.IndexByte(r.before, word[after]) < 0 {
+				continue
+			}
+		}
+		return r.sounds, len(r.pattern)
+	}
+	return []string{word[i : i+1]}, 1
+}
+
+// bmFold keeps a leading vowel (y counts as one) as "a", drops the others
+// and collapses repeated classes.
+func bmFold(sounds string) string {
+	var b strings.Builder
+	var last rune
+
+	for i, r := range sounds {
+		class, ok := bmApprox[r]
+		if !ok {
+			if i == 0 && strings.ContainsRune("aeiouy", r) {
+				b.WriteByte('a')
+			}
+			last = 0
+			continue
+		}
+		if class == last {
+			continue
+		}
+		b.WriteRune(class)
+		last = class
+	}
+
+	return b.String()
+}
@@ -0,0 +1,153 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("embedder circuit open")
+
+type BreakerConfig struct {
+	FailureThreshold int           // consecutive failures that open the circuit, defaults to 5
+	OpenFor          time.Duration // how long to fail fast before probing again, defaults to 10s
+}
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// CircuitBreaker stops calling a failing embedder. After FailureThreshold
+// consecutive failures every call fails fast with ErrCircuitOpen for OpenFor;
+// then a single probe is let through and its outcome closes or reopens the
+// circuit. Cancellations and deadlines of the caller are not held against
+// the backend.
+type CircuitBreaker struct {
+	backend Embedder
+	cfg     BreakerConfig
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewCircuitBreaker(backend Embedder, cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenFor <= 0 {
+		cfg.OpenFor = 10 * time.Second
+	}
+	return &CircuitBreaker{backend: backend, cfg: cfg}
+}
+
+func (b *CircuitBreaker) ModelID() string {
+	return b.backend.ModelID()
+}
+
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cfg.OpenFor {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+func (b *CircuitBreaker) Embed(ctx context.Context, text string) ([]float32, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+
+	vec, err := b.backend.Embed(ctx, text)
+	b.record(ctx, err)
+
+	return vec, err
+}
+
+func (b *CircuitBreaker) EmbedBatch(ctx context.Context, texts []string) ([]BatchResult, error) {
+	if err := b.allow(); err != nil {
+		return nil, err
+	}
+
+	results := EmbedAll(ctx, b.backend, texts)
+
+	// The backend is only considered broken if nothing came back.
+	var err error
+	for _, res := range results {
+		if res.Err == nil {
+			err = nil
+			break
+		}
+		err = res.Err
+	}
+	b.record(ctx, err)
+
+	return results, nil
+}
+
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cfg.OpenFor {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+func (b *CircuitBreaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up or ran out of its own time, such as a search's
+		// semantic budget; we learned nothing about the backend. The
+		// embedder's own timeout leaves ctx alone and does count.
+		return
+	}
+
+	if err == nil {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
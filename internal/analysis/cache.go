@@ -0,0 +1,211 @@
+package analysis
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type CacheConfig struct {
+	Capacity int    // entries kept in memory, defaults to 10000
+	Dir      string // optional directory for the persistent cache, empty disables it
+}
+
+type CacheStats struct {
+	Hits     uint64 // served from memory
+	DiskHits uint64 // served from the on-disk cache
+	Misses   uint64 // had to ask the backend
+	Entries  int    // entries currently held in memory
+}
+
+type cacheKey struct {
+	model string
+	text  string
+}
+
+type cacheEntry struct {
+	key    cacheKey
+	vector []float32
+}
+
+// CachedEmbedder memoizes another embedder. Entries are keyed by the backend's
+// model ID and the normalized text, kept in an LRU in memory and, when a
+// directory is configured, appended to an on-disk cache that survives restarts.
+type CachedEmbedder struct {
+	backend  Embedder
+	capacity int
+
+	mu    sync.Mutex
+	lru   *list.List
+	items map[cacheKey]*list.Element
+	disk  *diskCache
+
+	hits     atomic.Uint64
+	diskHits atomic.Uint64
+	misses   atomic.Uint64
+}
+
+func NewCachedEmbedder(backend Embedder, cfg CacheConfig) (*CachedEmbedder, error) {
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = 10000
+	}
+
+	c := &CachedEmbedder{
+		backend:  backend,
+		capacity: cfg.Capacity,
+		lru:      list.New(),
+		items:    make(map[cacheKey]*list.Element),
+	}
+
+	if cfg.Dir != "" {
+		disk, err := openDiskCache(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		c.disk = disk
+	}
+
+	return c, nil
+}
+
+// normalizeForCache folds case and whitespace so trivially different spellings
+// of the same text share one entry.
+func normalizeForCache(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+func (c *CachedEmbedder) ModelID() string {
+	return c.backend.ModelID()
+}
+
+func (c *CachedEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	key := cacheKey{model: c.backend.ModelID(), text: normalizeForCache(text)}
+	if vec, ok := c.lookup(key); ok {
+		return vec, nil
+	}
+
+	c.misses.Add(1)
+	vec, err := c.backend.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	if vec != nil {
+		c.store(key, vec)
+	}
+
+	return vec, nil
+}
+
+func (c *CachedEmbedder) EmbedBatch(ctx context.Context, texts []string) ([]BatchResult, error) {
+	model := c.backend.ModelID()
+	results := make([]BatchResult, len(texts))
+
+	var missTexts []string
+	var missKeys []cacheKey
+	var missSlots [][]int
+	missIndex := make(map[cacheKey]int)
+
+	for i, text := range texts {
+		key := cacheKey{model: model, text: normalizeForCache(text)}
+		if vec, ok := c.lookup(key); ok {
+			results[i].Embedding = vec
+			continue
+		}
+		if pos, ok := missIndex[key]; ok {
+			missSlots[pos] = append(missSlots[pos], i)
+			continue
+		}
+		c.misses.Add(1)
+		missIndex[key] = len(missTexts)
+		missTexts = append(missTexts, text)
+		missKeys = append(missKeys, key)
+		missSlots = append(missSlots, []int{i})
+	}
+
+	fetched := EmbedAll(ctx, c.backend, missTexts)
+	for pos, res := range fetched {
+		if res.Err == nil && res.Embedding != nil {
+			c.store(missKeys[pos], res.Embedding)
+		}
+		for _, i := range missSlots[pos] {
+			results[i] = res
+		}
+	}
+
+	return results, nil
+}
+
+func (c *CachedEmbedder) Stats() CacheStats {
+	c.mu.Lock()
+	entries := c.lru.Len()
+	c.mu.Unlock()
+
+	return CacheStats{
+		Hits:     c.hits.Load(),
+		DiskHits: c.diskHits.Load(),
+		Misses:   c.misses.Load(),
+		Entries:  entries,
+	}
+}
+
+// Close flushes and closes the on-disk cache.
+func (c *CachedEmbedder) Close() error {
+	if c.disk == nil {
+		return nil
+	}
+	return c.disk.Close()
+}
+
+func (c *CachedEmbedder) lookup(key cacheKey) ([]float32, bool) {
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.lru.MoveToFront(el)
+		vec := el.Value.(*cacheEntry).vector
+		c.mu.Unlock()
+		c.hits.Add(1)
+		return vec, true
+	}
+	c.mu.Unlock()
+
+	if c.disk == nil {
+		return nil, false
+	}
+
+	vec, ok := c.disk.Get(key)
+	if !ok {
+		return nil, false
+	}
+	c.diskHits.Add(1)
+	c.remember(key, vec)
+
+	return vec, true
+}
+
+func (c *CachedEmbedder) store(key cacheKey, vec []float32) {
+	c.remember(key, vec)
+	if c.disk != nil {
+		// A failed disk write only costs us a future miss.
+		_ = c.disk.Put(key, vec)
+	}
+}
+
+func (c *CachedEmbedder) remember(key cacheKey, vec []float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*cacheEntry).vector = vec
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.lru.PushFront(&cacheEntry{key: key, vector: vec})
+
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,208 @@
+package analysis
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+type shingleFilter struct {
+	min, max  int
+	separator string
+	unigrams  bool
+}
+
+// NewShingleFilter adds a token for every run of min to max adjacent words,
+// joined by separator, spanning the words it covers. With an empty separator
+// "data center" yields "datacenter" and meets the closed compound. Shingles
+// never bridge a gap left by a removed stop word. Without unigrams only the
+// shingles are kept.
+func NewShingleFilter(min, max int, separator string, unigrams bool) TokenFilter {
+	return &shingleFilter{min: min, max: max, separator: separator, unigrams: unigrams}
+}
+
+func (f *shingleFilter) Filter(tokens []Token) []Token {
+	var words []Token
+	for _, t := range tokens {
+		if !t.Keyword && !t.Synonym && t.Span() == 1 {
+			words = append(words, t)
+		}
+	}
+
+	var shingles []Token
+	for i := range words {
+		for n := f.min; n <= f.max && i+n <= len(words); n++ {
+			run := words[i : i+n]
+			if run[n-1].Position-run[0].Position != n-1 {
+				break
+			}
+			terms := make([]string, n)
+			for k, w := range run {
+				terms[k] = w.Term
+			}
+			shingles = append(shingles, Token{
+				Term:           strings.Join(terms, f.separator),
+				Start:          run[0].Start,
+				End:            run[n-1].End,
+				Position:       run[0].Position,
+				PositionLength: n,
+			})
+		}
+	}
+
+	if !f.unigrams {
+		var out []Token
+		for _, t := range tokens {
+			if t.Keyword || t.Synonym || t.Span() != 1 {
+				out = append(out, t)
+			}
+		}
+		return append(out, shingles...)
+	}
+	return append(tokens, shingles...)
+}
+
+type decompounder struct {
+	words   map[string]bool
+	minWord int
+	minPart int
+	linking []string
+}
+
+// NewDecompoundFilter splits words of at least minWord runes into parts
+// found in words, each at least minPart runes, allowing a linking element
+// such as German "s" between parts. The split with the fewest parts wins.
+// The parts follow one another in the token graph and the compound spans
+// them, so "datenbank" matches "daten bank" and the reverse.
+func NewDecompoundFilter(words []string, minWord, minPart int, linking []string) TokenFilter {
+	d := &decompounder{words: make(map[string]bool, len(words)), minWord: minWord, minPart: minPart, linking: linking}
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			// Also match after an earlier ascii_folding.
+			d.words[w] = true
+			d.words[FoldDiacritics(w)] = true
+		}
+	}
+	return d
+}
+
+// LoadWordList reads one word per line, skipping blanks and # comments.
+func LoadWordList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return words, nil
+}
+
+// split returns the parts of word, or nil if it is no compound of known
+// words.
+func (d *decompounder) split(word string) []string {
+	w := []rune(word)
+	n := len(w)
+
+	// best[i] is the fewest parts covering w[:i]; from[i] and part[i] lead
+	// back through the chosen split.
+	const none = -1
+	best := make([]int, n+1)
+	from := make([]int, n+1)
+	part := make([]string, n+1)
+	for i := range best {
+		best[i] = none
+	}
+	best[0] = 0
+
+	for i := 0; i < n; i++ {
+		if best[i] == none {
+			continue
+		}
+		for j := i + d.minPart; j <= n; j++ {
+			p := string(w[i:j])
+			if !d.words[p] {
+				continue
+			}
+			ends := []int{j}
+			if j < n {
+				for _, l := range d.linking {
+					if strings.HasPrefix(string(w[j:]), l) {
+						ends = append(ends, j+utf8.RuneCountInString(l))
+					}
+				}
+			}
+			for _, e := range ends {
+				if e > n || (e > j && e == n) {
+					continue
+				}
+				if best[e] == none || best[i]+1 < best[e] {
+					best[e], from[e], part[e] = best[i]+1, i, p
+				}
+			}
+		}
+	}
+	if best[n] < 2 {
+		return nil
+	}
+
+	parts := make([]string, best[n])
+	for i, k := n, best[n]-1; i > 0; i, k = from[i], k-1 {
+		parts[k] = part[i]
+	}
+	return parts
+}
+
+func (d *decompounder) Filter(tokens []Token) []Token {
+	var inserts []insertion
+	var parts []Token
+	for i, t := range tokens {
+		if t.Keyword || t.Synonym || utf8.RuneCountInString(t.Term) < d.minWord {
+			continue
+		}
+		split := d.split(t.Term)
+		if split == nil {
+			continue
+		}
+
+		pos := t.Position + shiftFor(inserts, t.Position)
+		// Offsets are only exact when the term still spells the source.
+		exact := t.End-t.Start == len(t.Term)
+		offset := t.Start
+		for k, p := range split {
+			pt := Token{Term: p, Start: t.Start, End: t.End, Position: pos + k}
+			if exact {
+				at := strings.Index(t.Term[offset-t.Start:], p)
+				if at >= 0 {
+					pt.Start = offset + at
+					pt.End = pt.Start + len(p)
+					offset = pt.End
+				}
+			}
+			parts = append(parts, pt)
+		}
+		tokens[i].PositionLength = t.Span() + len(split) - 1
+		inserts = append(inserts, insertion{at: t.Position + t.Span(), extra: len(split) - 1})
+	}
+	if len(parts) == 0 {
+		return tokens
+	}
+
+	for i := range tokens {
+		tokens[i].Position += shiftFor(inserts, tokens[i].Position)
+	}
+	return append(tokens, parts...)
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDecompoundSplit(t *testing.T) {
+	d := NewDecompoundFilter([]string{"daten", "bank", "haus", "tür", "arbeit", "amt", "bankhaus"}, 6, 3, []string{"s"}).(*decompounder)
+
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"datenbank", []string{"daten", "bank"}},
+		{"arbeitsamt", []string{"arbeit", "amt"}},
+		{"haustür", []string{"haus", "tür"}},
+		{"haustur", []string{"haus", "tur"}}, // after ascii_folding
+		{"datenbankhaus", []string{"daten", "bankhaus"}},
+		{"bank", nil},
+		{"datenbanks", nil},
+		{"datenbax", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := d.split(tt.word); !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecompoundPositions(t *testing.T) {
+	filter := NewDecompoundFilter([]string{"daten", "bank"}, 6, 3, nil)
+
+	got := graph(filter.Filter(WhitespaceSegmenter{}.Segment("die datenbank läuft")))
+	want := []string{"bank@2+1", "daten@1+1", "datenbank@1+2", "die@0+1", "läuft@3+1"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,196 @@
+package analysis
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const diskCacheFile = "embeddings.cache"
+
+type diskRef struct {
+	offset int64 // start of the vector payload
+	dims   int
+}
+
+// diskCache is an append-only log of (model, text, vector) records with an
+// in-memory offset table, so vectors are only read back when requested.
+//
+// Record layout: uvarint len(model) | model | uvarint len(text) | text |
+// uvarint dims | dims little-endian float32s.
+type diskCache struct {
+	mu    sync.RWMutex
+	file  *os.File
+	size  int64
+	index map[cacheKey]diskRef
+}
+
+func openDiskCache(dir string) (*diskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, diskCacheFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &diskCache{file: file, index: make(map[cacheKey]diskRef)}
+	if err := d.scan(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// scan rebuilds the offset table. A torn record at the tail (crash mid-write)
+// is cut off so later appends start on a record boundary.
+func (d *diskCache) scan() error {
+	r := &countingReader{r: bufio.NewReader(d.file)}
+
+	for {
+		start := r.n
+		key, dims, err := readRecordHeader(r)
+		if err == nil {
+			vecStart := r.n
+			if _, err = io.CopyN(io.Discard, r, int64(dims)*4); err == nil {
+				d.index[key] = diskRef{offset: vecStart, dims: dims}
+				continue
+			}
+		}
+
+		if errors.Is(err, io.EOF) && r.n == start {
+			d.size = start
+			return nil
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			d.size = start
+			return d.file.Truncate(start)
+		}
+		return fmt.Errorf("embedding cache corrupt at offset %d: %w", start, err)
+	}
+}
+
+func readRecordHeader(r *countingReader) (cacheKey, int, error) {
+	var key cacheKey
+	var err error
+
+	if key.model, err = readString(r); err != nil {
+		return key, 0, err
+	}
+	if key.text, err = readString(r); err != nil {
+		return key, 0, unexpected(err)
+	}
+	dims, err := binary.ReadUvarint(r)
+	if err != nil {
+		return key, 0, unexpected(err)
+	}
+
+	return key, int(dims), nil
+}
+
+func readString(r *countingReader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", unexpected(err)
+	}
+	return string(buf), nil
+}
+
+func unexpected(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (d *diskCache) Get(key cacheKey) ([]float32, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	ref, ok := d.index[key]
+	if !ok {
+		return nil, false
+	}
+
+	buf := make([]byte, ref.dims*4)
+	if _, err := d.file.ReadAt(buf, ref.offset); err != nil {
+		return nil, false
+	}
+
+	vec := make([]float32, ref.dims)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+	}
+
+	return vec, true
+}
+
+func (d *diskCache) Put(key cacheKey, vec []float32) error {
+	var buf []byte
+	buf = binary.AppendUvarint(buf, uint64(len(key.model)))
+	buf = append(buf, key.model...)
+	buf = binary.AppendUvarint(buf, uint64(len(key.text)))
+	buf = append(buf, key.text...)
+	buf = binary.AppendUvarint(buf, uint64(len(vec)))
+	header := len(buf)
+	for _, v := range vec {
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(v))
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.index[key]; ok {
+		return nil
+	}
+
+	if _, err := d.file.WriteAt(buf, d.size); err != nil {
+		return err
+	}
+	d.index[key] = diskRef{offset: d.size + int64(header), dims: len(vec)}
+	d.size += int64(len(buf))
+
+	return nil
+}
+
+func (d *diskCache) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.file.Sync(); err != nil {
+		d.file.Close()
+		return err
+	}
+	return d.file.Close()
+}
+
+type countingReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
@@ -0,0 +1,578 @@
+package analysis
+
+import "strings"
+
+const metaphoneMaxLen = 4
+
+// DoubleMetaphone returns the primary and alternate Double Metaphone codes
+// (Lawrence Philips, 2000) of a word, each at most four characters. The
+// alternate is empty when the word has only one likely pronunciation.
+func DoubleMetaphone(word string) (string, string) {
+	value := strings.ToUpper(strings.TrimSpace(FoldDiacritics(word)))
+	if value == "" {
+		return "", ""
+	}
+
+	m := &metaphone{value: value, slavoGermanic: isSlavoGermanic(value)}
+	m.encode()
+
+	primary, alternate := m.primary.String(), m.alternate.String()
+	if alternate == primary {
+		alternate = ""
+	}
+	return primary, alternate
+}
+
+type metaphone struct {
+	value              string
+	slavoGermanic      bool
+	primary, alternate strings.Builder
+}
+
+func isSlavoGermanic(value string) bool {
+	return strings.ContainsAny(value, "WK") || strings.Contains(value, "CZ") || strings.Contains(value, "WITZ")
+}
+
+func (m *metaphone) at(i int) byte {
+	if i < 0 || i >= len(m.value) {
+		return 0
+	}
+	return m.value[i]
+}
+
+// has reports whether the substring of length n at start is one of options.
+func (m *metaphone) has(start, n int, options ...string) bool {
+	if start < 0 || start+n > len(m.value) {
+		return false
+	}
+	sub := m.value[start : start+n]
+	for _, o := range options {
+		if sub == o {
+			return true
+		}
+	}
+	return false
+}
+
+func isMetaphoneVowel(c byte) bool {
+	return strings.IndexByte("AEIOUY", c) >= 0
+}
+
+func (m *metaphone) done() bool {
+	return m.primary.Len() >= metaphoneMaxLen && m.alternate.Len() >= metaphoneMaxLen
+}
+
+func appendCapped(b *strings.Builder, s string) {
+	if room := metaphoneMaxLen - b.Len(); room > 0 {
+		if len(s) > room {
+			s = s[:room]
+		}
+		b.WriteString(s)
+	}
+}
+
+func (m *metaphone) add(primary string, alternate ...string) {
+	appendCapped(&m.primary, primary)
+	if len(alternate) > 0 {
+		appendCapped(&m.alternate, alternate[0])
+	} else {
+		appendCapped(&m.alternate, primary)
+	}
+}
+
+func (m *metaphone) addAlternate(s string) {
+	appendCapped(&m.alternate, s)
+}
+
+func (m *metaphone) addPrimary(s string) {
+	appendCapped(&m.primary, s)
+}
+
+// skip returns i+2 if the next letter is c (a doubled letter), else i+1.
+func (m *metaphone) skip(i int, c byte) int {
+	if m.at(i+1) == c {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (m *metaphone) encode() {
+	i := 0
+	if m.has(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		i = 1
+	}
+	if m.at(0) == 'X' {
+		// Initial X is pronounced Z, as in Xavier.
+		m.add("S")
+		i = 1
+	}
+
+	for !m.done() && i < len(m.value) {
+		switch c := m.value[i]; c {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if i == 0 {
+				m.add("A")
+			}
+			i++
+		case 'B':
+			m.add("P")
+			i = m.skip(i, 'B')
+		case 'C':
+			i = m.handleC(i)
+		case 'D':
+			i = m.handleD(i)
+		case 'F':
+			m.add("F")
+			i = m.skip(i, 'F')
+		case 'G':
+			i = m.handleG(i)
+		case 'H':
+			i = m.handleH(i)
+		case 'J':
+			i = m.handleJ(i)
+		case 'K':
+			m.add("K")
+			i = m.skip(i, 'K')
+		case 'L':
+			i = m.handleL(i)
+		case 'M':
+			m.add("M")
+			if m.conditionM0(i) {
+				i += 2
+			} else {
+				i++
+			}
+		case 'N':
+			m.add("N")
+			i = m.skip(i, 'N')
+		case 'P':
+			i = m.handleP(i)
+		case 'Q':
+			m.add("K")
+			i = m.skip(i, 'Q')
+		case 'R':
+			i = m.handleR(i)
+		case 'S':
+			i = m.handleS(i)
+		case 'T':
+			i = m.handleT(i)
+		case 'V':
+			m.add("F")
+			i = m.skip(i, 'V')
+		case 'W':
+			i = m.handleW(i)
+		case 'X':
+			i = m.handleX(i)
+		case 'Z':
+			i = m.handleZ(i)
+		default:
+			i++
+		}
+	}
+}
+
+func (m *metaphone) handleC(i int) int {
+	switch {
+	case m.conditionC0(i):
+		m.add("K")
+		return i + 2
+	case i == 0 && m.has(i, 6, "CAESAR"):
+		m.add("S")
+		return i + 2
+	case m.has(i, 2, "CH"):
+		return m.handleCH(i)
+	case m.has(i, 2, "CZ") && !m.has(i-2, 4, "WICZ"):
+		m.add("S", "X")
+		return i + 2
+	case m.has(i+1, 3, "CIA"):
+		m.add("X")
+		return i + 3
+	case m.has(i, 2, "CC") && !(i == 1 && m.at(0) == 'M'):
+		return m.handleCC(i)
+	case m.has(i, 2, "CK", "CG", "CQ"):
+		m.add("K")
+		return i + 2
+	case m.has(i, 2, "CI", "CE", "CY"):
+		if m.has(i, 3, "CIO", "CIE", "CIA") {
+			m.add("S", "X")
+		} else {
+			m.add("S")
+		}
+		return i + 2
+	}
+
+	m.add("K")
+	switch {
+	case m.has(i+1, 2, " C", " Q", " G"):
+		return i + 3
+	case m.has(i+1, 1, "C", "K", "Q") && !m.has(i+1, 2, "CE", "CI"):
+		return i + 2
+	}
+	return i + 1
+}
+
+func (m *metaphone) handleCC(i int) int {
+	if m.has(i+2, 1, "I", "E", "H") && !m.has(i+2, 2, "HU") {
+		if (i == 1 && m.at(i-1) == 'A') || m.has(i-1, 5, "UCCEE", "UCCES") {
+			m.add("KS")
+		} else {
+			m.add("X")
+		}
+		return i + 3
+	}
+	m.add("K")
+	return i + 2
+}
+
+func (m *metaphone) handleCH(i int) int {
+	switch {
+	case i > 0 && m.has(i, 4, "CHAE"):
+		m.add("K", "X")
+	case m.conditionCH0(i), m.conditionCH1(i):
+		m.add("K")
+	case i > 0:
+		if m.has(0, 2, "MC") {
+			m.add("K")
+		} else {
+			m.add("X", "K")
+		}
+	default:
+		m.add("X")
+	}
+	return i + 2
+}
+
+func (m *metaphone) conditionC0(i int) bool {
+	if m.has(i, 4, "CHIA") {
+		return true
+	}
+	if i <= 1 || isMetaphoneVowel(m.at(i-2)) || !m.has(i-1, 3, "ACH") {
+		return false
+	}
+	c := m.at(i + 2)
+	return (c != 'I' && c != 'E') || m.has(i-2, 6, "BACHER", "MACHER")
+}
+
+func (m *metaphone) conditionCH0(i int) bool {
+	if i != 0 {
+		return false
+	}
+	if !m.has(i+1, 5, "HARAC", "HARIS") && !m.has(i+1, 3, "HOR", "HYM", "HIA", "HEM") {
+		return false
+	}
+	return !m.has(0, 5, "CHORE")
+}
+
+func (m *metaphone) conditionCH1(i int) bool {
+	return m.has(0, 4, "VAN ", "VON ") || m.has(0, 3, "SCH") ||
+		m.has(i-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		m.has(i+2, 1, "T", "S") ||
+		((m.has(i-1, 1, "A", "O", "U", "E") || i == 0) &&
+			(m.has(i+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") || i+1 == len(m.value)-1))
+}
+
+func (m *metaphone) handleD(i int) int {
+	switch {
+	case m.has(i, 2, "DG"):
+		if m.has(i+2, 1, "I", "E", "Y") {
+			m.add("J")
+			return i + 3
+		}
+		m.add("TK")
+		return i + 2
+	case m.has(i, 2, "DT", "DD"):
+		m.add("T")
+		return i + 2
+	}
+	m.add("T")
+	return i + 1
+}
+
+func (m *metaphone) handleG(i int) int {
+	switch {
+	case m.at(i+1) == 'H':
+		return m.handleGH(i)
+	case m.at(i+1) == 'N':
+		switch {
+		case i == 1 && isMetaphoneVowel(m.at(0)) && !m.slavoGermanic:
+			m.add("KN", "N")
+		case !m.has(i+2, 2, "EY") && m.at(i+1) != 'Y' && !m.slavoGermanic:
+			m.add("N", "KN")
+		default:
+			m.add("KN")
+		}
+		return i + 2
+	case m.has(i+1, 2, "LI") && !m.slavoGermanic:
+		m.add("KL", "L")
+		return i + 2
+	case i == 0 && (m.at(i+1) == 'Y' || m.has(i+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		m.add("K", "J")
+		return i + 2
+	case (m.has(i+1, 2, "ER") || m.at(i+1) == 'Y') &&
+		!m.has(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!m.has(i-1, 1, "E", "I") && !m.has(i-1, 3, "RGY", "OGY"):
+		m.add("K", "J")
+		return i + 2
+	case m.has(i+1, 1, "E", "I", "Y") || m.has(i-1, 4, "AGGI", "OGGI"):
+		switch {
+		case m.has(0, 4, "VAN ", "VON ") || m.has(0, 3, "SCH") || m.has(i+1, 2, "ET"):
+			m.add("K")
+		case m.has(i+1, 3, "IER"):
+			m.add("J")
+		default:
+			m.add("J", "K")
+		}
+		return i + 2
+	case m.at(i+1) == 'G':
+		m.add("K")
+		return i + 2
+	}
+	m.add("K")
+	return i + 1
+}
+
+func (m *metaphone) handleGH(i int) int {
+	switch {
+	case i > 0 && !isMetaphoneVowel(m.at(i-1)):
+		m.add("K")
+	case i == 0:
+		if m.at(i+2) == 'I' {
+			m.add("J")
+		} else {
+			m.add("K")
+		}
+	case (i > 1 && m.has(i-2, 1, "B", "H", "D")) ||
+		(i > 2 && m.has(i-3, 1, "B", "H", "D")) ||
+		(i > 3 && m.has(i-4, 1, "B", "H")):
+		// Silent, as in "hugh" or "bough".
+	default:
+		if i > 2 && m.at(i-1) == 'U' && m.has(i-3, 1, "C", "G", "L", "R", "T") {
+			m.add("F")
+		} else if i > 0 && m.at(i-1) != 'I' {
+			m.add("K")
+		}
+	}
+	return i + 2
+}
+
+func (m *metaphone) handleH(i int) int {
+	if (i == 0 || isMetaphoneVowel(m.at(i-1))) && isMetaphoneVowel(m.at(i+1)) {
+		m.add("H")
+		return i + 2
+	}
+	return i + 1
+}
+
+func (m *metaphone) handleJ(i int) int {
+	if m.has(i, 4, "JOSE") || m.has(0, 4, "SAN ") {
+		if (i == 0 && m.at(i+4) == ' ') || len(m.value) == 4 || m.has(0, 4, "SAN ") {
+			m.add("H")
+		} else {
+			m.add("J", "H")
+		}
+		return i + 1
+	}
+
+	switch {
+	case i == 0:
+		m.add("J", "A")
+	case isMetaphoneVowel(m.at(i-1)) && !m.slavoGermanic && (m.at(i+1) == 'A' || m.at(i+1) == 'O'):
+		m.add("J", "H")
+	case i == len(m.value)-1:
+		m.add("J", "")
+	case !m.has(i+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !m.has(i-1, 1, "S", "K", "L"):
+		m.add("J")
+	}
+	return m.skip(i, 'J')
+}
+
+func (m *metaphone) handleL(i int) int {
+	if m.at(i+1) == 'L' {
+		if m.conditionL0(i) {
+			m.addPrimary("L")
+		} else {
+			m.add("L")
+		}
+		return i + 2
+	}
+	m.add("L")
+	return i + 1
+}
+
+func (m *metaphone) conditionL0(i int) bool {
+	n := len(m.value)
+	if i == n-3 && m.has(i-1, 4, "ILLO", "ILLA", "ALLE") {
+		return true
+	}
+	return (m.has(n-2, 2, "AS", "OS") || m.has(n-1, 1, "A", "O")) && m.has(i-1, 4, "ALLE")
+}
+
+func (m *metaphone) conditionM0(i int) bool {
+	if m.at(i+1) == 'M' {
+		return true
+	}
+	return m.has(i-1, 3, "UMB") && (i+1 == len(m.value)-1 || m.has(i+2, 2, "ER"))
+}
+
+func (m *metaphone) handleP(i int) int {
+	if m.at(i+1) == 'H' {
+		m.add("F")
+		return i + 2
+	}
+	m.add("P")
+	if m.has(i+1, 1, "P", "B") {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (m *metaphone) handleR(i int) int {
+	// French final -ier, as in "Rogier".
+	if i == len(m.value)-1 && !m.slavoGermanic && m.has(i-2, 2, "IE") && !m.has(i-4, 2, "ME", "MA") {
+		m.addAlternate("R")
+	} else {
+		m.add("R")
+	}
+	return m.skip(i, 'R')
+}
+
+func (m *metaphone) handleS(i int) int {
+	switch {
+	case m.has(i-1, 3, "ISL", "YSL"):
+		// Silent, as in "island".
+		return i + 1
+	case i == 0 && m.has(i, 5, "SUGAR"):
+		m.add("X", "S")
+		return i + 1
+	case m.has(i, 2, "SH"):
+		if m.has(i+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			m.add("S")
+		} else {
+			m.add("X")
+		}
+		return i + 2
+	case m.has(i, 3, "SIO", "SIA") || m.has(i, 4, "SIAN"):
+		if m.slavoGermanic {
+			m.add("S")
+		} else {
+			m.add("S", "X")
+		}
+		return i + 3
+	case (i == 0 && m.has(i+1, 1, "M", "N", "L", "W")) || m.has(i+1, 1, "Z"):
+		m.add("S", "X")
+		if m.has(i+1, 1, "Z") {
+			return i + 2
+		}
+		return i + 1
+	case m.has(i, 2, "SC"):
+		return m.handleSC(i)
+	}
+
+	if i == len(m.value)-1 && m.has(i-2, 2, "AI", "OI") {
+		// French final -ais, -ois.
+		m.addAlternate("S")
+	} else {
+		m.add("S")
+	}
+	if m.has(i+1, 1, "S", "Z") {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (m *metaphone) handleSC(i int) int {
+	switch {
+	case m.at(i+2) == 'H':
+		switch {
+		case m.has(i+3, 2, "OO", "ER", "EN", "UY", "ED", "EM"):
+			if m.has(i+3, 2, "ER", "EN") {
+				m.add("X", "SK")
+			} else {
+				m.add("SK")
+			}
+		case i == 0 && !isMetaphoneVowel(m.at(3)) && m.at(3) != 'W':
+			m.add("X", "S")
+		default:
+			m.add("X")
+		}
+	case m.has(i+2, 1, "I", "E", "Y"):
+		m.add("S")
+	default:
+		m.add("SK")
+	}
+	return i + 3
+}
+
+func (m *metaphone) handleT(i int) int {
+	switch {
+	case m.has(i, 4, "TION"), m.has(i, 3, "TIA", "TCH"):
+		m.add("X")
+		return i + 3
+	case m.has(i, 2, "TH") || m.has(i, 3, "TTH"):
+		if m.has(i+2, 2, "OM", "AM") || m.has(0, 4, "VAN ", "VON ") || m.has(0, 3, "SCH") {
+			m.add("T")
+		} else {
+			m.add("0", "T")
+		}
+		return i + 2
+	}
+	m.add("T")
+	if m.has(i+1, 1, "T", "D") {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (m *metaphone) handleW(i int) int {
+	if m.has(i, 2, "WR") {
+		m.add("R")
+		return i + 2
+	}
+
+	switch {
+	case i == 0 && (isMetaphoneVowel(m.at(i+1)) || m.has(i, 2, "WH")):
+		if isMetaphoneVowel(m.at(i + 1)) {
+			m.add("A", "F")
+		} else {
+			m.add("A")
+		}
+	case (i == len(m.value)-1 && isMetaphoneVowel(m.at(i-1))) ||
+		m.has(i-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || m.has(0, 3, "SCH"):
+		m.addAlternate("F")
+	case m.has(i, 4, "WICZ", "WITZ"):
+		m.add("TS", "FX")
+		return i + 4
+	}
+	return i + 1
+}
+
+func (m *metaphone) handleX(i int) int {
+	if i == 0 {
+		m.add("S")
+		return i + 1
+	}
+
+	// Silent in French endings such as "breaux".
+	last := i == len(m.value)-1
+	if !(last && (m.has(i-3, 3, "IAU", "EAU") || m.has(i-2, 2, "AU", "OU"))) {
+		m.add("KS")
+	}
+	if m.has(i+1, 1, "C", "X") {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (m *metaphone) handleZ(i int) int {
+	if m.at(i+1) == 'H' {
+		m.add("J")
+		return i + 2
+	}
+
+	if m.has(i+1, 2, "ZO", "ZI", "ZA") || (m.slavoGermanic && i > 0 && m.at(i-1) != 'T') {
+		m.add("S", "TS")
+	} else {
+		m.add("S")
+	}
+	return m.skip(i, 'Z')
+}
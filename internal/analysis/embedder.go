@@ -2,12 +2,24 @@ package analysis
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
+// Embedder turns a piece of text into a dense vector. Implementations must be
+// safe for concurrent use since the index embeds from several goroutines.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+	// ModelID names the model behind the vectors, vectors from different
+	// models must never be compared or cached under the same key.
+	ModelID() string
+}
+
 type EmbedRequest struct {
 	Text string `json:"text"`
 }
@@ -16,28 +28,123 @@ type EmbedResponse struct {
 	Embedding []float32 `json:"embedding"`
 }
 
-var nerveClient = &http.Client{
-	Timeout: 10 * time.Second, // Give the AI time to think
+type EmbedBatchRequest struct {
+	Texts []string `json:"texts"`
+}
+
+// EmbedBatchResponse carries one slot per requested text; a non-null entry in
+// Errors means the matching embedding is missing.
+type EmbedBatchResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+	Errors     []*string   `json:"errors"`
+}
+
+const (
+	DefaultNerveURL   = "http://localhost:5000"
+	DefaultNerveModel = "all-MiniLM-L6-v2"
+)
+
+type NerveConfig struct {
+	URL         string        // base URL of the Python nerve, defaults to DefaultNerveURL
+	Model       string        // model served by the nerve, defaults to DefaultNerveModel
+	Timeout     time.Duration // whole request timeout, defaults to 10s
+	DialTimeout time.Duration // connection timeout, defaults to 2s
+}
+
+// NerveEmbedder talks to the Python sentence-transformer service in /nerve.
+type NerveEmbedder struct {
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+func NewNerveEmbedder(cfg NerveConfig) *NerveEmbedder {
+	if cfg.URL == "" {
+		cfg.URL = DefaultNerveURL
+	}
+	if cfg.Model == "" {
+		cfg.Model = DefaultNerveModel
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second // Give the AI time to think
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = 2 * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: cfg.DialTimeout}).DialContext
+
+	return &NerveEmbedder{
+		baseURL: strings.TrimRight(cfg.URL, "/"),
+		model:   cfg.Model,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+		},
+	}
+}
+
+func (n *NerveEmbedder) ModelID() string {
+	return n.model
 }
 
-func GetEmbedding(text string) ([]float32, error) {
-	reqBody, _ := json.Marshal(EmbedRequest{Text: text})
+func (n *NerveEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	var res EmbedResponse
+	if err := n.post(ctx, "/embed", EmbedRequest{Text: text}, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Embedding, nil
+}
+
+func (n *NerveEmbedder) EmbedBatch(ctx context.Context, texts []string) ([]BatchResult, error) {
+	var res EmbedBatchResponse
+	if err := n.post(ctx, "/embed_batch", EmbedBatchRequest{Texts: texts}, &res); err != nil {
+		return nil, err
+	}
+
+	if len(res.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("nerve returned %d embeddings for %d texts", len(res.Embeddings), len(texts))
+	}
+
+	results := make([]BatchResult, len(texts))
+	for i := range texts {
+		if i < len(res.Errors) && res.Errors[i] != nil {
+			results[i].Err = fmt.Errorf("nerve failed to embed item %d: %s", i, *res.Errors[i])
+			continue
+		}
+		results[i].Embedding = res.Embeddings[i]
+	}
 
-	// Talk to the Python Nerve on Port 5000
-	resp, err := nerveClient.Post("http://localhost:5000/embed", "application/json", bytes.NewBuffer(reqBody))
+	return results, nil
+}
+
+func (n *NerveEmbedder) post(ctx context.Context, path string, body any, out any) error {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.baseURL+path, bytes.NewReader(reqBody))
 	if err != nil {
-		return nil, fmt.Errorf("nerve offline: %w", err)
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("nerve offline: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("nerve returned error: %d", resp.StatusCode)
+		return fmt.Errorf("nerve returned error: %d", resp.StatusCode)
 	}
 
-	var res EmbedResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode nerve response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode nerve response: %w", err)
 	}
 
-	return res.Embedding, nil
+	return nil
 }
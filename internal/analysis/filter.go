@@ -0,0 +1,163 @@
+package analysis
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenFilter rewrites, drops or adds tokens. Filters run in order after the
+// tokenizer and may change Term but keep offsets pointing at the original
+// text.
+type TokenFilter interface {
+	Filter(tokens []Token) []Token
+}
+
+// TokenFilterFunc adapts a plain function to TokenFilter.
+type TokenFilterFunc func(tokens []Token) []Token
+
+func (f TokenFilterFunc) Filter(tokens []Token) []Token {
+	return f(tokens)
+}
+
+// EnglishStopWords is the stop list of the standard analyzer. Contractions
+// are listed without the apostrophe, the way the tokenizer emits them.
+var EnglishStopWords = []string{
+	"a", "about", "above", "after", "again", "against", "all", "am", "an", "and", "any", "are", "as", "at", "be", "because", "been", "before", "being", "below", "between", "both", "but", "by", "can", "did", "do", "does", "doing", "don", "down", "during", "each", "few", "for", "from", "further", "had", "has", "have", "having", "he", "her", "here", "hers", "herself", "him", "himself", "his", "how", "i", "if", "in", "into", "is", "it", "its", "itself", "just", "me", "more", "most", "my", "myself", "no", "nor", "not", "now", "of", "off", "on", "once", "only", "or", "other", "our", "ours", "ourselves", "out", "over", "own", "s", "same", "she", "should", "so", "some", "such", "t", "than", "that", "the", "their", "theirs", "them", "themselves", "then", "there", "these", "they", "this", "those", "through", "to", "too", "under", "until", "up", "very", "was", "we", "were", "what", "when", "where", "which", "while", "who", "whom", "why", "will", "with", "you", "your", "yours", "yourself", "yourselves",
+	"arent", "cant", "couldnt", "didnt", "doesnt", "dont", "hadnt", "hasnt", "havent", "isnt", "shouldnt", "wasnt", "werent", "wont", "wouldnt",
+}
+
+var porter = New()
+
+// LowercaseFilter lower-cases every term. Greek final sigma folds to sigma
+// so word-final and medial forms match.
+var LowercaseFilter TokenFilter = TokenFilterFunc(func(tokens []Token) []Token {
+	for i := range tokens {
+		tokens[i].Term = strings.ReplaceAll(strings.ToLower(tokens[i].Term), "ς", "σ")
+	}
+	return tokens
+})
+
+// ASCIIFoldingFilter strips accents, so "café" and "cafe" match.
+var ASCIIFoldingFilter TokenFilter = TokenFilterFunc(func(tokens []Token) []Token {
+	for i := range tokens {
+		tokens[i].Term = FoldDiacritics(tokens[i].Term)
+	}
+	return tokens
+})
+
+// PorterStemFilter stems English words. Terms with non-ASCII letters and
+// Keyword tokens are left alone.
+var PorterStemFilter TokenFilter = TokenFilterFunc(func(tokens []Token) []Token {
+	out := tokens[:0]
+	for _, t := range tokens {
+		if !t.Keyword && isASCII(t.Term) {
+			t.Term = porter.Stem(t.Term)
+		}
+		if t.Term != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+})
+
+type stopFilter struct {
+	words map[string]bool
+}
+
+// NewStopFilter drops tokens whose term is in words. Positions are not
+// renumbered, so phrases keep their gaps.
+func NewStopFilter(words []string) TokenFilter {
+	f := &stopFilter{words: make(map[string]bool, len(words))}
+	for _, w := range words {
+		f.words[w] = true
+	}
+	return f
+}
+
+func (f *stopFilter) Filter(tokens []Token) []Token {
+	out := tokens[:0]
+	for _, t := range tokens {
+		if t.Keyword || !f.words[t.Term] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+type ngramFilter struct {
+	min, max int
+	edge     bool
+}
+
+// NewNGramFilter replaces each term by its character n-grams of min to max
+// runes. Terms shorter than min are kept whole.
+func NewNGramFilter(min, max int) TokenFilter {
+	return &ngramFilter{min: min, max: max}
+}
+
+// NewEdgeNGramFilter replaces each term by its prefixes of min to max runes.
+// Terms shorter than min are kept whole.
+func NewEdgeNGramFilter(min, max int) TokenFilter {
+	return &ngramFilter{min: min, max: max, edge: true}
+}
+
+func (f *ngramFilter) Filter(tokens []Token) []Token {
+	var out []Token
+	for _, t := range tokens {
+		runes := []rune(t.Term)
+		if len(runes) < f.min {
+			out = append(out, t)
+			continue
+		}
+		starts := len(runes)
+		if f.edge {
+			starts = 1
+		}
+		for i := 0; i < starts; i++ {
+			for n := f.min; n <= f.max && i+n <= len(runes); n++ {
+				gram := t
+				gram.Term = string(runes[i : i+n])
+				out = append(out, gram)
+			}
+		}
+	}
+	return out
+}
+
+type phoneticFilter struct {
+	encoder PhoneticEncoder
+	keep    bool
+}
+
+// NewPhoneticFilter emits the encoder's codes at the position of each term,
+// after the term itself when keepOriginal is set.
+func NewPhoneticFilter(encoder PhoneticEncoder, keepOriginal bool) TokenFilter {
+	return &phoneticFilter{encoder: encoder, keep: keepOriginal}
+}
+
+func (f *phoneticFilter) Filter(tokens []Token) []Token {
+	var out []Token
+	for _, t := range tokens {
+		if f.keep || t.Keyword {
+			out = append(out, t)
+		}
+		if t.Keyword {
+			continue
+		}
+		for _, code := range f.encoder.Encode(t.Term) {
+			c := t
+			c.Term = code
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
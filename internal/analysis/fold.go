@@ -0,0 +1,44 @@
+package analysis
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Letters that do not decompose into a base letter plus marks.
+var foldSpecial = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "AE", 'ø': "o", 'Ø': "O", 'œ': "oe", 'Œ': "OE",
+	'ł': "l", 'Ł': "L", 'đ': "d", 'Đ': "D", 'ð': "d", 'Ð': "D", 'þ': "th", 'Þ': "TH",
+	'ı': "i",
+}
+
+// Scripts whose combining marks are accents rather than part of the letter.
+// Kana voicing marks or Indic vowel signs change the word and are kept.
+var accentedScripts = []*unicode.RangeTable{unicode.Latin, unicode.Greek, unicode.Cyrillic, unicode.Arabic, unicode.Hebrew}
+
+// FoldDiacritics strips accents and other combining marks, so "Müller"
+// becomes "Muller" and "Ørsted" becomes "Orsted".
+func FoldDiacritics(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	strip := false
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			if strip {
+				continue
+			}
+		} else {
+			strip = unicode.In(r, accentedScripts...)
+		}
+		if repl, ok := foldSpecial[r]; ok {
+			b.WriteString(repl)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return norm.NFC.String(b.String())
+}
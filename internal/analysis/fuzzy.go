@@ -1,53 +1,191 @@
 package analysis
 
-const MAX_DISTANCE = 3
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
 
-func Levenshtein(s1, s2 string) (int, bool) {
+// EditCosts prices each edit operation of DamerauLevenshtein.
+type EditCosts struct {
+	Insert     float64
+	Delete     float64
+	Substitute float64
+	Transpose  float64 // swapping two neighboring runes, "teh" -> "the"
+	Adjacent   float64 // substituting a key next to it on a QWERTY keyboard, 0 means Substitute
+}
+
+var (
+	// UnitCosts gives the classic distance: every edit costs 1.
+	UnitCosts = EditCosts{Insert: 1, Delete: 1, Substitute: 1, Transpose: 1}
+
+	// TypoCosts makes slips of the finger cheaper than other edits.
+	TypoCosts = EditCosts{Insert: 1, Delete: 1, Substitute: 1, Transpose: 0.75, Adjacent: 0.5}
+)
 
-	if len(s1) > len(s2) {
-		s1, s2 = s2, s1
+var qwertyRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// qwertyNeighbors holds each key's neighbors on its own row and the
+// staggered rows above and below.
+var qwertyNeighbors = func() map[rune]map[rune]bool {
+	pos := make(map[rune][2]int)
+	for r, row := range qwertyRows {
+		for c, key := range row {
+			pos[key] = [2]int{r, c}
+		}
 	}
 
-	n, m := len(s1), len(s2)
+	at := func(r, c int) (rune, bool) {
+		if r < 0 || r >= len(qwertyRows) || c < 0 || c >= len(qwertyRows[r]) {
+			return 0, false
+		}
+		return rune(qwertyRows[r][c]), true
+	}
 
-	prevRow := make([]int, n+1)
-	currRow := make([]int, n+1)
+	neighbors := make(map[rune]map[rune]bool)
+	for key, p := range pos {
+		neighbors[key] = make(map[rune]bool)
+		r, c := p[0], p[1]
+		for _, d := range [][2]int{{0, -1}, {0, 1}, {-1, 0}, {-1, 1}, {1, -1}, {1, 0}} {
+			if n, ok := at(r+d[0], c+d[1]); ok {
+				neighbors[key][n] = true
+			}
+		}
+	}
+	return neighbors
+}()
 
-	// distance from an empty string (each block)
-	for i := 0; i <= n; i++ {
-		prevRow[i] = i
+func (c EditCosts) substitution(a, b rune) float64 {
+	if c.Adjacent > 0 && qwertyNeighbors[a][b] {
+		return c.Adjacent
 	}
+	return c.Substitute
+}
 
-	var cost int
+// DamerauLevenshtein is the restricted (optimal string alignment) edit
+// distance between a and b, computed over runes. It gives up and reports
+// false as soon as the distance must exceed limit.
+func DamerauLevenshtein(a, b string, costs EditCosts, limit float64) (float64, bool) {
+	s, t := []rune(a), []rune(b)
 
-	for j := 1; j <= m; j++ {
+	// Rows for t[:j-2], t[:j-1] and t[:j] against every prefix of s.
+	prev2 := make([]float64, len(s)+1)
+	prev := make([]float64, len(s)+1)
+	curr := make([]float64, len(s)+1)
 
-		currRow[0] = j
-		for i := 1; i <= n; i++ {
+	for i := range prev {
+		prev[i] = float64(i) * costs.Delete
+	}
+	prevMin := 0.0
 
-			cost = 1
+	for j := 1; j <= len(t); j++ {
+		curr[0] = float64(j) * costs.Insert
+		rowMin := curr[0]
 
-			if s1[i-1] == s2[j-1] {
-				cost = 0
+		for i := 1; i <= len(s); i++ {
+			sub := 0.0
+			if s[i-1] != t[j-1] {
+				sub = costs.substitution(s[i-1], t[j-1])
 			}
 
-			// deletion , insertion , substitution
-			currRow[i] = min(prevRow[i]+1, currRow[i-1]+1, prevRow[i-1]+cost)
-		}
-
-		stopCalculation := true
-		for _, val := range currRow {
-			if val <= MAX_DISTANCE {
-				stopCalculation = false
-				break
+			curr[i] = min(prev[i]+costs.Insert, curr[i-1]+costs.Delete, prev[i-1]+sub)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] && s[i-1] != s[i-2] {
+				curr[i] = min(curr[i], prev2[i-2]+costs.Transpose)
 			}
+			rowMin = min(rowMin, curr[i])
 		}
 
-		if stopCalculation {
+		// A transposition can still reach back to the previous row.
+		if rowMin > limit && prevMin+costs.Transpose > limit {
 			return 0, false
 		}
-		copy(prevRow, currRow)
+		prevMin = rowMin
+		prev2, prev, curr = prev, curr, prev2
 	}
 
-	return prevRow[n], true
+	dist := prev[len(s)]
+	return dist, dist <= limit
+}
+
+// Fuzziness is how many edits a term may be away from what it matches: a
+// fixed Distance, or AUTO, which scales with term length like Elasticsearch
+// does.
+type Fuzziness struct {
+	Auto     bool
+	AutoLow  int     // terms shorter than this must match exactly, defaults to 3
+	AutoHigh int     // terms shorter than this allow one edit, longer two, defaults to 6
+	Distance float64 // used when Auto is false
+}
+
+var AutoFuzziness = Fuzziness{Auto: true}
+
+// MaxEdits is the budget for a term of n runes.
+func (f Fuzziness) MaxEdits(n int) float64 {
+	if !f.Auto {
+		return f.Distance
+	}
+
+	low, high := f.AutoLow, f.AutoHigh
+	if low <= 0 {
+		low = 3
+	}
+	if high <= 0 {
+		high = 6
+	}
+
+	switch {
+	case n < low:
+		return 0
+	case n < high:
+		return 1
+	}
+	return 2
+}
+
+func (f Fuzziness) String() string {
+	if !f.Auto {
+		return strconv.FormatFloat(f.Distance, 'g', -1, 64)
+	}
+	if f.AutoLow == 0 && f.AutoHigh == 0 {
+		return "AUTO"
+	}
+	return fmt.Sprintf("AUTO:%d,%d", f.AutoLow, f.AutoHigh)
+}
+
+// ParseFuzziness reads "AUTO", "AUTO:low,high" or a distance such as "1"
+// or "1.5".
+func ParseFuzziness(s string) (Fuzziness, error) {
+	s = strings.TrimSpace(s)
+
+	if rest, ok := strings.CutPrefix(strings.ToUpper(s), "AUTO"); ok {
+		f := Fuzziness{Auto: true}
+		if rest == "" {
+			return f, nil
+		}
+		bounds, ok := strings.CutPrefix(rest, ":")
+		lowStr, highStr, found := strings.Cut(bounds, ",")
+		if !ok || !found {
+			return f, fmt.Errorf("fuzziness %q: want AUTO:low,high", s)
+		}
+		low, err := strconv.Atoi(strings.TrimSpace(lowStr))
+		if err != nil {
+			return f, fmt.Errorf("fuzziness %q: %w", s, err)
+		}
+		high, err := strconv.Atoi(strings.TrimSpace(highStr))
+		if err != nil {
+			return f, fmt.Errorf("fuzziness %q: %w", s, err)
+		}
+		if low <= 0 || high < low {
+			return f, fmt.Errorf("fuzziness %q: want 0 < low <= high", s)
+		}
+		f.AutoLow, f.AutoHigh = low, high
+		return f, nil
+	}
+
+	d, err := strconv.ParseFloat(s, 64)
+	if err != nil || d < 0 || math.IsInf(d, 0) || math.IsNaN(d) {
+		return Fuzziness{}, fmt.Errorf("fuzziness %q: want AUTO, AUTO:low,high or a non-negative distance", s)
+	}
+	return Fuzziness{Distance: d}, nil
 }
@@ -0,0 +1,63 @@
+package analysis
+
+import "testing"
+
+func TestDamerauLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		costs  EditCosts
+		limit  float64
+		want   float64
+		wantOK bool
+	}{
+		{"search", "search", UnitCosts, 0, 0, true},
+		{"serch", "search", UnitCosts, 1, 1, true},
+		{"saerch", "search", UnitCosts, 1, 1, true},
+		{"saerch", "search", TypoCosts, 1, 0.75, true},
+		{"searcj", "search", TypoCosts, 1, 0.5, true},
+		{"searcx", "search", TypoCosts, 1, 1, true},
+		{"srch", "search", UnitCosts, 2, 2, true},
+		{"srch", "search", UnitCosts, 1, 0, false},
+		{"ca", "abc", UnitCosts, 3, 3, true}, // optimal string alignment, not 2
+		{"kitten", "sitting", UnitCosts, 2, 0, false},
+		{"kitten", "sitting", UnitCosts, 3, 3, true},
+		{"über", "uber", UnitCosts, 1, 1, true},
+		{"", "abc", UnitCosts, 3, 3, true},
+		{"abc", "", UnitCosts, 2, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			got, ok := DamerauLevenshtein(tt.a, tt.b, tt.costs, tt.limit)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("got %g, %v; want %g, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFuzzinessMaxEdits(t *testing.T) {
+	tests := []struct {
+		fuzziness string
+		runes     int
+		want      float64
+	}{
+		{"AUTO", 2, 0},
+		{"AUTO", 3, 1},
+		{"AUTO", 5, 1},
+		{"AUTO", 6, 2},
+		{"AUTO:4,8", 3, 0},
+		{"AUTO:4,8", 7, 1},
+		{"AUTO:4,8", 8, 2},
+		{"1.5", 2, 1.5},
+		{"0", 10, 0},
+	}
+	for _, tt := range tests {
+		f, err := ParseFuzziness(tt.fuzziness)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.fuzziness, err)
+		}
+		if got := f.MaxEdits(tt.runes); got != tt.want {
+			t.Errorf("%s with %d runes: got %g, want %g", tt.fuzziness, tt.runes, got, tt.want)
+		}
+	}
+}
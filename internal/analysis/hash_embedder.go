@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const DefaultHashDims = 384
+
+// HashEmbedder is the "Concept-to-Vector" transformer: a deterministic
+// feature-hashing embedder that needs no model and no network. Every word and
+// every character trigram of that word is hashed into one of dims buckets with
+// a hashed sign, so texts sharing words (or spellings) land close together.
+type HashEmbedder struct {
+	dims int
+}
+
+func NewHashEmbedder(dims int) *HashEmbedder {
+	if dims <= 0 {
+		dims = DefaultHashDims
+	}
+	return &HashEmbedder{dims: dims}
+}
+
+func (h *HashEmbedder) ModelID() string {
+	return fmt.Sprintf("hash-%d", h.dims)
+}
+
+func (h *HashEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	vec := make([]float32, h.dims)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for _, word := range words {
+		h.add(vec, "w:"+word, 1.0)
+
+		// trigrams of the padded word give typo-tolerant overlap
+		runes := []rune("^" + word + "$")
+		for i := 0; i+3 <= len(runes); i++ {
+			h.add(vec, "g:"+string(runes[i:i+3]), 0.5)
+		}
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v * v)
+	}
+	if norm == 0 {
+		return vec, nil
+	}
+
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vec {
+		vec[i] *= scale
+	}
+
+	return vec, nil
+}
+
+func (h *HashEmbedder) add(vec []float32, feature string, weight float32) {
+	hasher := fnv.New64a()
+	hasher.Write([]byte(feature))
+	sum := hasher.Sum64()
+
+	bucket := sum % uint64(h.dims)
+	if sum&(1<<63) != 0 {
+		weight = -weight
+	}
+	vec[bucket] += weight
+}
@@ -0,0 +1,232 @@
+package analysis
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxProfileGram = 3
+
+	// minDetectLetters is the least text worth guessing about; below it
+	// every language scores alike.
+	minDetectLetters = 4
+	// minDetectMargin is the average log-likelihood per n-gram by which the
+	// best language must beat the runner-up.
+	minDetectMargin = 0.1
+)
+
+// ngramProfile holds the character n-gram counts of a language's sample
+// text, words padded with '_' so prefixes and suffixes count apart.
+type ngramProfile struct {
+	counts map[string]int
+	totals [maxProfileGram + 1]int
+}
+
+func ngrams(text string, fn func(gram string, n int)) {
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		runes := []rune("_" + word + "_")
+		for n := 1; n <= maxProfileGram; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				if gram := string(runes[i : i+n]); gram != "_" {
+					fn(gram, n)
+				}
+			}
+		}
+	}
+}
+
+func newNgramProfile(text string) *ngramProfile {
+	p := &ngramProfile{counts: make(map[string]int)}
+	ngrams(text, func(gram string, n int) {
+		p.counts[gram]++
+		p.totals[n]++
+	})
+	return p
+}
+
+// logProb is the add-one smoothed log-probability of gram among the
+// profile's n-grams of the same length.
+func (p *ngramProfile) logProb(gram string, n int) float64 {
+	return math.Log(float64(p.counts[gram]+1) / float64(p.totals[n]+len(p.counts)))
+}
+
+// LanguageDetector identifies the language of a text by comparing its
+// n-gram profile with one per language.
+type LanguageDetector struct {
+	profiles map[string]*ngramProfile
+}
+
+// NewLanguageDetector builds a profile from the sample text of each
+// language, keyed by canonical language name.
+func NewLanguageDetector(samples map[string]string) *LanguageDetector {
+	d := &LanguageDetector{profiles: make(map[string]*ngramProfile, len(samples))}
+	for lang, text := range samples {
+		d.profiles[lang] = newNgramProfile(text)
+	}
+	return d
+}
+
+// DefaultLanguageDetector knows the languages that have a stemmer.
+var DefaultLanguageDetector = NewLanguageDetector(languageSamples())
+
+// Detect returns the language of text and how clearly it won: the gap in
+// average log-likelihood per n-gram to the runner-up. It returns "" when the
+// text is too short or too close to call.
+func (d *LanguageDetector) Detect(text string) (string, float64) {
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	if letters < minDetectLetters || len(d.profiles) == 0 {
+		return "", 0
+	}
+
+	scores := make(map[string]float64, len(d.profiles))
+	grams := 0
+	ngrams(text, func(gram string, n int) {
+		grams++
+		for lang, p := range d.profiles {
+			scores[lang] += p.logProb(gram, n)
+		}
+	})
+
+	best, second := "", math.Inf(-1)
+	bestScore := math.Inf(-1)
+	for lang, score := range scores {
+		switch {
+		case score > bestScore || (score == bestScore && lang < best):
+			second = bestScore
+			best, bestScore = lang, score
+		case score > second:
+			second = score
+		}
+	}
+
+	if len(scores) == 1 {
+		return best, math.Inf(1)
+	}
+	margin := (bestScore - second) / float64(grams)
+	if margin < minDetectMargin {
+		return "", margin
+	}
+	return best, margin
+}
+
+// languageSamples joins each language's sample prose with its stop words,
+// which carry most of the signal in short texts.
+func languageSamples() map[string]string {
+	samples := make(map[string]string, len(sampleTexts))
+	for lang, text := range sampleTexts {
+		samples[lang] = text + " " + strings.Join(StopWords[lang], " ")
+	}
+	return samples
+}
+
+var sampleTexts = map[string]string{
+	"english": `The search engine reads every document that arrives, breaks the
+text into words and remembers where each word was found. When somebody asks
+a question, the engine looks up the words of the question and ranks the
+documents that contain them, putting the ones that mention the words most
+often and most closely together at the top. People usually type only a few
+words, often with spelling mistakes, so the engine also tries nearby words
+and words that mean the same thing. Good results depend on understanding
+what the person was thinking about when they wrote the query, which is why
+modern systems combine the exact wording with the meaning of the whole
+sentence. The weather was warm and bright through the whole of the summer,
+and the children played outside in the garden until the evening came.
+
+A relational database stores records in tables and answers queries written
+in a declarative language, while the algorithm that plans each query decides
+which indexes to read. Machine learning models are trained on large data
+sets, and their predictions improve with better features and more examples.
+Global warming is driven by greenhouse gases, and scientists measure the
+temperature of the oceans and the atmosphere every year. The government
+announced new policies for healthcare, education and transportation, which
+were discussed by the committee during the following weeks. Software
+engineers write code, review changes, fix bugs and deploy services that
+handle thousands of requests per second without losing performance. This
+library provides functions for reading, writing and searching through
+structured information stored on the server.`,
+
+	"german": `Die Suchmaschine liest jedes Dokument, das ankommt, zerlegt den
+Text in Wörter und merkt sich, wo jedes Wort gefunden wurde. Wenn jemand eine
+Frage stellt, schlägt die Maschine die Wörter der Frage nach und ordnet die
+Dokumente, die sie enthalten, sodass diejenigen, welche die Wörter am
+häufigsten und am nächsten beieinander erwähnen, ganz oben stehen. Menschen
+schreiben meistens nur wenige Wörter, oft mit Rechtschreibfehlern, deshalb
+versucht die Maschine auch ähnliche Wörter und solche, die dasselbe bedeuten.
+Gute Ergebnisse hängen davon ab, zu verstehen, woran die Person beim
+Schreiben der Anfrage gedacht hat. Das Wetter war den ganzen Sommer über warm
+und schön, und die Kinder spielten bis zum Abend draußen im Garten. Häuser,
+Straßen und Brücken wurden in der Stadt gebaut.
+
+Eine relationale Datenbank speichert Datensätze in Tabellen und beantwortet
+Abfragen, während der Algorithmus, der jede Abfrage plant, entscheidet,
+welche Indizes gelesen werden. Modelle des maschinellen Lernens werden mit
+großen Datenmengen trainiert, und ihre Vorhersagen verbessern sich mit
+besseren Merkmalen und mehr Beispielen. Die Erderwärmung wird durch
+Treibhausgase verursacht, und Wissenschaftler messen jedes Jahr die
+Temperatur der Ozeane und der Atmosphäre. Die Regierung kündigte neue
+Maßnahmen für Gesundheit, Bildung und Verkehr an, die in den folgenden
+Wochen vom Ausschuss besprochen wurden. Softwareentwickler schreiben
+Programme, prüfen Änderungen, beheben Fehler und betreiben Dienste, die
+tausende Anfragen pro Sekunde verarbeiten. Diese Bibliothek stellt
+Funktionen zum Lesen, Schreiben und Durchsuchen von Informationen bereit.`,
+
+	"french": `Le moteur de recherche lit chaque document qui arrive, découpe le
+texte en mots et retient l'endroit où chaque mot a été trouvé. Lorsque
+quelqu'un pose une question, le moteur cherche les mots de la question et
+classe les documents qui les contiennent, en plaçant en tête ceux qui
+mentionnent les mots le plus souvent et le plus près les uns des autres. Les
+gens tapent généralement seulement quelques mots, souvent avec des fautes
+d'orthographe, c'est pourquoi le moteur essaie aussi des mots voisins et des
+mots qui veulent dire la même chose. De bons résultats dépendent de la
+compréhension de ce à quoi la personne pensait en écrivant sa requête. Le
+temps était chaud et lumineux pendant tout l'été, et les enfants jouaient
+dehors dans le jardin jusqu'au soir.
+
+Une base de données relationnelle stocke les enregistrements dans des tables
+et répond aux requêtes, tandis que l'algorithme qui planifie chaque requête
+décide quels index lire. Les modèles d'apprentissage automatique sont
+entraînés sur de grands ensembles de données, et leurs prédictions
+s'améliorent avec de meilleures caractéristiques et davantage d'exemples. Le
+réchauffement climatique est causé par les gaz à effet de serre, et les
+scientifiques mesurent chaque année la température des océans et de
+l'atmosphère. Le gouvernement a annoncé de nouvelles mesures pour la santé,
+l'éducation et les transports, qui ont été discutées par la commission au
+cours des semaines suivantes. Les ingénieurs écrivent du code, vérifient les
+modifications, corrigent les erreurs et déploient des services qui traitent
+des milliers de demandes par seconde. Cette bibliothèque fournit des
+fonctions pour lire, écrire et rechercher des informations.`,
+
+	"spanish": `El motor de búsqueda lee cada documento que llega, divide el
+texto en palabras y recuerda dónde se encontró cada palabra. Cuando alguien
+hace una pregunta, el motor busca las palabras de la pregunta y ordena los
+documentos que las contienen, poniendo arriba los que mencionan las palabras
+con más frecuencia y más cerca unas de otras. La gente suele escribir solo
+unas pocas palabras, a menudo con errores de ortografía, por eso el motor
+también prueba palabras cercanas y palabras que significan lo mismo. Los
+buenos resultados dependen de entender en qué pensaba la persona cuando
+escribió la consulta. El tiempo fue cálido y luminoso durante todo el verano,
+y los niños jugaron afuera en el jardín hasta que llegó la noche. Las
+canciones nacionales de la ciudad son muy antiguas.
+
+Una base de datos relacional almacena registros en tablas y responde a
+consultas, mientras que el algoritmo que planifica cada consulta decide qué
+índices leer. Los modelos de aprendizaje automático se entrenan con grandes
+conjuntos de datos, y sus predicciones mejoran con mejores características y
+más ejemplos. El calentamiento global es causado por los gases de efecto
+invernadero, y los científicos miden cada año la temperatura de los océanos
+y de la atmósfera. El gobierno anunció nuevas medidas para la salud, la
+educación y el transporte, que fueron discutidas por la comisión durante las
+semanas siguientes. Los ingenieros escriben código, revisan cambios,
+corrigen errores y despliegan servicios que atienden miles de solicitudes
+por segundo. Esta biblioteca ofrece funciones para leer, escribir y buscar
+información almacenada en el servidor.`,
+}
@@ -24,3 +24,28 @@ func CosineSimilarity(a, b []float32) float32 {
 
 	return float32(dot / (math.Sqrt(selfA) * math.Sqrt(selfB)))
 }
+
+func DotProduct(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0.0
+	}
+
+	var dot float64
+	for i := range a {
+		dot += float64(a[i] * b[i])
+	}
+	return float32(dot)
+}
+
+func EuclideanDistance(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return float32(math.Inf(1))
+	}
+
+	var sum float64
+	for i := range a {
+		d := float64(a[i] - b[i])
+		sum += d * d
+	}
+	return float32(math.Sqrt(sum))
+}
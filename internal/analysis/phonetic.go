@@ -1,9 +1,61 @@
 package analysis
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
+
+// PhoneticEncoder maps a term to the codes of how it sounds. Encoders that
+// cannot decide between pronunciations return several codes.
+type PhoneticEncoder interface {
+	Name() string
+	Encode(term string) []string
+}
+
+type soundexEncoder struct{}
+
+func (soundexEncoder) Name() string { return "soundex" }
+
+func (soundexEncoder) Encode(term string) []string {
+	if code := Soundex(term); code != "" {
+		return []string{code}
+	}
+	return nil
+}
+
+type doubleMetaphoneEncoder struct{}
+
+func (doubleMetaphoneEncoder) Name() string { return "double_metaphone" }
+
+func (doubleMetaphoneEncoder) Encode(term string) []string {
+	primary, alternate := DoubleMetaphone(term)
+	switch {
+	case primary == "":
+		return nil
+	case alternate == "" || alternate == primary:
+		return []string{primary}
+	}
+	return []string{primary, alternate}
+}
+
+var (
+	SoundexEncoder         PhoneticEncoder = soundexEncoder{}
+	DoubleMetaphoneEncoder PhoneticEncoder = doubleMetaphoneEncoder{}
+	BeiderMorseEncoder     PhoneticEncoder = beiderMorseEncoder{}
+)
+
+// PhoneticEncoderByName looks an encoder up by its Name.
+func PhoneticEncoderByName(name string) (PhoneticEncoder, error) {
+	for _, enc := range []PhoneticEncoder{SoundexEncoder, DoubleMetaphoneEncoder, BeiderMorseEncoder} {
+		if enc.Name() == name {
+			return enc, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown phonetic encoder %q", name)
+}
 
 func strip(input string) string {
-	input = strings.ToUpper(input)
+	input = strings.ToUpper(FoldDiacritics(input))
 
 	var results strings.Builder
 	for _, r := range input {
@@ -0,0 +1,461 @@
+package analysis
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Params are the settings of one configured component, as decoded from
+// JSON: numbers arrive as float64 and lists as []any.
+type Params map[string]any
+
+func (p Params) Int(key string, def int) (int, error) {
+	v, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+	switch n := v.(type) {
+	case float64:
+		return int(n), nil
+	case int:
+		return n, nil
+	}
+	return 0, fmt.Errorf("%s must be a number, got %T", key, v)
+}
+
+func (p Params) Bool(key string, def bool) (bool, error) {
+	v, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("%s must be a boolean, got %T", key, v)
+	}
+	return b, nil
+}
+
+func (p Params) String(key, def string) (string, error) {
+	v, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string, got %T", key, v)
+	}
+	return s, nil
+}
+
+func (p Params) Strings(key string) ([]string, error) {
+	v, ok := p[key]
+	if !ok {
+		return nil, nil
+	}
+	switch list := v.(type) {
+	case []string:
+		return list, nil
+	case []any:
+		out := make([]string, len(list))
+		for i, item := range list {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a list of strings", key)
+			}
+			out[i] = s
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("%s must be a list of strings, got %T", key, v)
+}
+
+type (
+	CharFilterFactory  func(Params) (CharFilter, error)
+	TokenizerFactory   func(Params) (Segmenter, error)
+	TokenFilterFactory func(Params) (TokenFilter, error)
+)
+
+// Registry maps component type names to factories. Built-in components are
+// registered on DefaultRegistry; Go code can add its own before the index
+// configuration is built.
+type Registry struct {
+	mu          sync.RWMutex
+	charFilters map[string]CharFilterFactory
+	tokenizers  map[string]TokenizerFactory
+	filters     map[string]TokenFilterFactory
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		charFilters: make(map[string]CharFilterFactory),
+		tokenizers:  make(map[string]TokenizerFactory),
+		filters:     make(map[string]TokenFilterFactory),
+	}
+}
+
+func (r *Registry) RegisterCharFilter(name string, f CharFilterFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.charFilters[name] = f
+}
+
+func (r *Registry) RegisterTokenizer(name string, f TokenizerFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokenizers[name] = f
+}
+
+func (r *Registry) RegisterTokenFilter(name string, f TokenFilterFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.filters[name] = f
+}
+
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.RegisterCharFilter("html_strip", func(Params) (CharFilter, error) {
+		return HTMLStripCharFilter, nil
+	})
+
+	DefaultRegistry.RegisterTokenizer("standard", func(p Params) (Segmenter, error) {
+		bigrams, err := p.Bool("cjk_bigrams", true)
+		if err != nil {
+			return nil, err
+		}
+		return &UnicodeSegmenter{CJKBigrams: bigrams}, nil
+	})
+	DefaultRegistry.RegisterTokenizer("whitespace", func(Params) (Segmenter, error) {
+		return WhitespaceSegmenter{}, nil
+	})
+	DefaultRegistry.RegisterTokenizer("keyword", func(Params) (Segmenter, error) {
+		return KeywordSegmenter{}, nil
+	})
+
+	DefaultRegistry.RegisterTokenFilter("lowercase", func(Params) (TokenFilter, error) {
+		return LowercaseFilter, nil
+	})
+	DefaultRegistry.RegisterTokenFilter("ascii_folding", func(Params) (TokenFilter, error) {
+		return ASCIIFoldingFilter, nil
+	})
+	DefaultRegistry.RegisterTokenFilter("porter_stem", func(Params) (TokenFilter, error) {
+		return PorterStemFilter, nil
+	})
+	DefaultRegistry.RegisterTokenFilter("stop", func(p Params) (TokenFilter, error) {
+		words, err := p.Strings("words")
+		if err != nil {
+			return nil, err
+		}
+		if words == nil {
+			lang, err := p.String("language", "english")
+			if err != nil {
+				return nil, err
+			}
+			name, err := CanonicalLanguage(lang)
+			if err != nil {
+				return nil, err
+			}
+			words = StopWords[name]
+		}
+		return NewStopFilter(words), nil
+	})
+	DefaultRegistry.RegisterTokenFilter("stemmer", func(p Params) (TokenFilter, error) {
+		lang, err := p.String("language", "english")
+		if err != nil {
+			return nil, err
+		}
+		stemmer, err := StemmerFor(lang)
+		if err != nil {
+			return nil, err
+		}
+		keep, err := p.Bool("keep_original", false)
+		if err != nil {
+			return nil, err
+		}
+		return NewStemFilter(stemmer, keep), nil
+	})
+	DefaultRegistry.RegisterTokenFilter("ngram", ngramFactory(NewNGramFilter))
+	DefaultRegistry.RegisterTokenFilter("edge_ngram", ngramFactory(NewEdgeNGramFilter))
+	DefaultRegistry.RegisterTokenFilter("phonetic", func(p Params) (TokenFilter, error) {
+		name, err := p.String("encoder", "soundex")
+		if err != nil {
+			return nil, err
+		}
+		encoder, err := PhoneticEncoderByName(name)
+		if err != nil {
+			return nil, err
+		}
+		keep, err := p.Bool("keep_original", true)
+		if err != nil {
+			return nil, err
+		}
+		return NewPhoneticFilter(encoder, keep), nil
+	})
+	DefaultRegistry.RegisterTokenFilter("shingle", func(p Params) (TokenFilter, error) {
+		min, err := p.Int("min_size", 2)
+		if err != nil {
+			return nil, err
+		}
+		max, err := p.Int("max_size", min)
+		if err != nil {
+			return nil, err
+		}
+		if min < 2 || max < min {
+			return nil, fmt.Errorf("shingle sizes must satisfy 2 <= min_size <= max_size, got %d and %d", min, max)
+		}
+		separator, err := p.String("separator", "")
+		if err != nil {
+			return nil, err
+		}
+		unigrams, err := p.Bool("output_unigrams", true)
+		if err != nil {
+			return nil, err
+		}
+		return NewShingleFilter(min, max, separator, unigrams), nil
+	})
+	DefaultRegistry.RegisterTokenFilter("decompound", func(p Params) (TokenFilter, error) {
+		path, err := p.String("path", "")
+		if err != nil {
+			return nil, err
+		}
+		words, err := p.Strings("words")
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case path != "" && words != nil:
+			return nil, fmt.Errorf("set either path or words, not both")
+		case path != "":
+			if words, err = LoadWordList(path); err != nil {
+				return nil, err
+			}
+		case words == nil:
+			return nil, fmt.Errorf("a word list (path or words) is required")
+		}
+		minWord, err := p.Int("min_word", 5)
+		if err != nil {
+			return nil, err
+		}
+		minPart, err := p.Int("min_subword", 3)
+		if err != nil {
+			return nil, err
+		}
+		if minPart < 1 {
+			return nil, fmt.Errorf("min_subword must be at least 1, got %d", minPart)
+		}
+		linking, err := p.Strings("linking")
+		if err != nil {
+			return nil, err
+		}
+		return NewDecompoundFilter(words, minWord, minPart, linking), nil
+	})
+	DefaultRegistry.RegisterTokenFilter("synonym_graph", func(p Params) (TokenFilter, error) {
+		path, err := p.String("path", "")
+		if err != nil {
+			return nil, err
+		}
+		format, err := p.String("format", "solr")
+		if err != nil {
+			return nil, err
+		}
+		inline, err := p.Strings("synonyms")
+		if err != nil {
+			return nil, err
+		}
+
+		var set *SynonymSet
+		switch {
+		case path != "" && inline != nil:
+			return nil, fmt.Errorf("set either path or synonyms, not both")
+		case path != "":
+			if set, err = LoadSynonymFile(path, format); err != nil {
+				return nil, err
+			}
+		default:
+			rules, err := ParseSolrSynonyms(strings.NewReader(strings.Join(inline, "\n")))
+			if err != nil {
+				return nil, err
+			}
+			set = NewSynonymSet(rules)
+		}
+		return NewSynonymGraphFilter(set, nil), nil
+	})
+}
+
+// chainUser is implemented by filters that analyze their own configuration,
+// like synonym rules, with the stages that run before them.
+type chainUser interface {
+	useChain(chain *Analyzer)
+}
+
+func ngramFactory(build func(min, max int) TokenFilter) TokenFilterFactory {
+	return func(p Params) (TokenFilter, error) {
+		min, err := p.Int("min", 3)
+		if err != nil {
+			return nil, err
+		}
+		max, err := p.Int("max", min)
+		if err != nil {
+			return nil, err
+		}
+		if min < 1 || max < min {
+			return nil, fmt.Errorf("n-gram sizes must satisfy 1 <= min <= max, got %d and %d", min, max)
+		}
+		return build(min, max), nil
+	}
+}
+
+// ComponentConfig names a registered type and its settings.
+type ComponentConfig struct {
+	Type   string `json:"type"`
+	Params Params `json:"params,omitempty"`
+}
+
+// AnalyzerConfig lists components by name: either one declared in the same
+// Config or a registered type used with its defaults.
+type AnalyzerConfig struct {
+	CharFilters []string `json:"char_filters,omitempty"`
+	Tokenizer   string   `json:"tokenizer"`
+	Filters     []string `json:"filters,omitempty"`
+}
+
+// Config declares the analyzers of an index. Components with settings are
+// declared once under a name and referenced from any analyzer.
+type Config struct {
+	CharFilters map[string]ComponentConfig `json:"char_filters,omitempty"`
+	Tokenizers  map[string]ComponentConfig `json:"tokenizers,omitempty"`
+	Filters     map[string]ComponentConfig `json:"filters,omitempty"`
+	Analyzers   map[string]AnalyzerConfig  `json:"analyzers,omitempty"`
+}
+
+// BuiltinAnalyzers are available to every index without being declared.
+var BuiltinAnalyzers = map[string]AnalyzerConfig{
+	"standard":   {Tokenizer: "standard", Filters: []string{"lowercase", "ascii_folding", "stop", "porter_stem"}},
+	"simple":     {Tokenizer: "standard", Filters: []string{"lowercase", "ascii_folding"}},
+	"whitespace": {Tokenizer: "whitespace"},
+	"keyword":    {Tokenizer: "keyword"},
+	"english":    languageAnalyzer("english"),
+	"german":     languageAnalyzer("german"),
+	"french":     languageAnalyzer("french"),
+	"spanish":    languageAnalyzer("spanish"),
+}
+
+// BuiltinFilters are preconfigured filters the built-in analyzers refer to;
+// a Config may reference or override them like its own.
+var BuiltinFilters = languageFilters()
+
+func languageFilters() map[string]ComponentConfig {
+	filters := make(map[string]ComponentConfig)
+	for lang := range stemmers {
+		filters[lang+"_stop"] = ComponentConfig{Type: "stop", Params: Params{"language": lang}}
+		filters[lang+"_stemmer"] = ComponentConfig{Type: "stemmer", Params: Params{"language": lang}}
+	}
+	return filters
+}
+
+// languageAnalyzer stems before folding, since the stemmers read accents.
+func languageAnalyzer(lang string) AnalyzerConfig {
+	return AnalyzerConfig{
+		Tokenizer: "standard",
+		Filters:   []string{"lowercase", lang + "_stop", lang + "_stemmer", "ascii_folding"},
+	}
+}
+
+// Build constructs every analyzer in cfg plus the built-in ones, which cfg
+// may override.
+func (r *Registry) Build(cfg Config) (map[string]*Analyzer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make(map[string]AnalyzerConfig, len(BuiltinAnalyzers)+len(cfg.Analyzers))
+	for name, def := range BuiltinAnalyzers {
+		defs[name] = def
+	}
+	for name, def := range cfg.Analyzers {
+		defs[name] = def
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	analyzers := make(map[string]*Analyzer, len(defs))
+	for _, name := range names {
+		a, err := r.build(name, defs[name], cfg)
+		if err != nil {
+			return nil, fmt.Errorf("analyzer %q: %w", name, err)
+		}
+		analyzers[name] = a
+	}
+	return analyzers, nil
+}
+
+func (r *Registry) build(name string, def AnalyzerConfig, cfg Config) (*Analyzer, error) {
+	a := &Analyzer{Name: name}
+
+	for _, ref := range def.CharFilters {
+		comp := resolve(ref, cfg.CharFilters)
+		factory, ok := r.charFilters[comp.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown char filter %q", comp.Type)
+		}
+		f, err := factory(comp.Params)
+		if err != nil {
+			return nil, fmt.Errorf("char filter %q: %w", ref, err)
+		}
+		a.CharFilters = append(a.CharFilters, f)
+	}
+
+	if def.Tokenizer == "" {
+		return nil, fmt.Errorf("no tokenizer")
+	}
+	comp := resolve(def.Tokenizer, cfg.Tokenizers)
+	factory, ok := r.tokenizers[comp.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown tokenizer %q", comp.Type)
+	}
+	tokenizer, err := factory(comp.Params)
+	if err != nil {
+		return nil, fmt.Errorf("tokenizer %q: %w", def.Tokenizer, err)
+	}
+	a.Tokenizer = tokenizer
+
+	for _, ref := range def.Filters {
+		comp, ok := cfg.Filters[ref]
+		if !ok {
+			comp = resolve(ref, BuiltinFilters)
+		}
+		factory, ok := r.filters[comp.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown token filter %q", comp.Type)
+		}
+		f, err := factory(comp.Params)
+		if err != nil {
+			return nil, fmt.Errorf("token filter %q: %w", ref, err)
+		}
+		if c, ok := f.(chainUser); ok {
+			c.useChain(&Analyzer{
+				CharFilters: a.CharFilters,
+				Tokenizer:   a.Tokenizer,
+				Filters:     slices.Clone(a.Filters),
+			})
+		}
+		a.Filters = append(a.Filters, f)
+	}
+
+	return a, nil
+}
+
+// resolve looks a name up among the declared components, falling back to a
+// registered type of that name with default settings.
+func resolve(name string, declared map[string]ComponentConfig) ComponentConfig {
+	if comp, ok := declared[name]; ok {
+		return comp
+	}
+	return ComponentConfig{Type: name}
+}
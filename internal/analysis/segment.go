@@ -0,0 +1,235 @@
+package analysis
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// segment is a run of text the tokenizer treats as a unit before
+// normalization, with its byte offsets in the original text.
+type segment struct {
+	text       string
+	start, end int
+	kind       segmentKind
+}
+
+type segmentKind int
+
+const (
+	segWord segmentKind = iota
+	segCJK              // a run of Han, Hiragana or Katakana
+	segURL
+	segEmail
+)
+
+var (
+	urlPattern   = regexp.MustCompile(`(?i)\b(?:https?://|ftp://|www\.)[^\s<>"']+`)
+	emailPattern = regexp.MustCompile(`[\p{L}\p{N}._%+-]+@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+`)
+)
+
+// segmentText splits text into URLs, emails, CJK runs and words. Words follow
+// the Unicode word-boundary rules (UAX #29) closely enough for search:
+// letters joined by apostrophes or middle dots stay one word ("don't"),
+// digits joined by "." or "," stay one number ("3.14", "1,000"), and words
+// joined by single hyphens are grouped ("state-of-the-art").
+func segmentText(text string) [][]segment {
+	var groups [][]segment
+
+	cursor := 0
+	for _, m := range specialSpans(text) {
+		groups = append(groups, segmentWords(text, cursor, m.start)...)
+		groups = append(groups, []segment{m})
+		cursor = m.end
+	}
+	groups = append(groups, segmentWords(text, cursor, len(text))...)
+
+	return groups
+}
+
+// specialSpans finds URLs and emails, trimmed of trailing punctuation.
+func specialSpans(text string) []segment {
+	var spans []segment
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		end := loc[0] + len(strings.TrimRight(text[loc[0]:loc[1]], ".,;:!?)]}"))
+		spans = append(spans, segment{text: text[loc[0]:end], start: loc[0], end: end, kind: segURL})
+	}
+	for _, loc := range emailPattern.FindAllStringIndex(text, -1) {
+		end := loc[0] + len(strings.TrimRight(text[loc[0]:loc[1]], ".-"))
+		if overlapsAny(spans, loc[0], end) {
+			continue
+		}
+		spans = append(spans, segment{text: text[loc[0]:end], start: loc[0], end: end, kind: segEmail})
+	}
+
+	// Keep them in text order for the caller.
+	for i := 1; i < len(spans); i++ {
+		for j := i; j > 0 && spans[j].start < spans[j-1].start; j-- {
+			spans[j], spans[j-1] = spans[j-1], spans[j]
+		}
+	}
+	return spans
+}
+
+func overlapsAny(spans []segment, start, end int) bool {
+	for _, s := range spans {
+		if start < s.end && s.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+type runeClass int
+
+const (
+	classOther runeClass = iota
+	classLetter
+	classDigit
+	classExtend    // combining marks, joiners: stick to what precedes
+	classConnector // underscore joins everything
+	classMidLetter // joins letters: middle dot
+	classMidNum    // joins digits: , ;
+	classMidNumLet // joins letters or digits: . ' ’
+	classHyphen
+	classCJK
+)
+
+func classify(r rune) runeClass {
+	switch {
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+		return classCJK
+	case unicode.IsLetter(r):
+		return classLetter
+	case unicode.IsDigit(r):
+		return classDigit
+	case unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me), r == '‍':
+		return classExtend
+	case r == '_':
+		return classConnector
+	case r == '·':
+		return classMidLetter
+	case r == ',' || r == ';':
+		return classMidNum
+	case r == '.' || r == '\'' || r == '’':
+		return classMidNumLet
+	case r == '-' || r == '‐' || r == '‑':
+		return classHyphen
+	}
+	return classOther
+}
+
+func isWordClass(c runeClass) bool {
+	return c == classLetter || c == classDigit || c == classConnector
+}
+
+// segmentWords scans text[from:to] and returns hyphen groups of words; most
+// groups hold a single word.
+func segmentWords(text string, from, to int) [][]segment {
+	var groups [][]segment
+	var group []segment
+
+	flush := func() {
+		if len(group) > 0 {
+			groups = append(groups, group)
+			group = nil
+		}
+	}
+
+	peek := func(i int) (runeClass, int) {
+		if i >= to {
+			return classOther, 0
+		}
+		r, size := utf8.DecodeRuneInString(text[i:to])
+		return classify(r), size
+	}
+
+	i := from
+	for i < to {
+		class, size := peek(i)
+
+		switch {
+		case class == classCJK:
+			flush()
+			start := i
+			for i < to {
+				c, n := peek(i)
+				if c != classCJK && c != classExtend {
+					break
+				}
+				i += n
+			}
+			groups = append(groups, []segment{{text: text[start:i], start: start, end: i, kind: segCJK}})
+
+		case isWordClass(class):
+			start := i
+			last := class
+			i += size
+			for i < to {
+				c, n := peek(i)
+				if isWordClass(c) || c == classExtend {
+					if c != classExtend {
+						last = c
+					}
+					i += n
+					continue
+				}
+				// Mid punctuation only joins when the same kind follows.
+				next, m := peek(i + n)
+				joinsLetters := (c == classMidLetter || c == classMidNumLet) && last == classLetter && next == classLetter
+				joinsDigits := (c == classMidNum || c == classMidNumLet) && last == classDigit && next == classDigit
+				if (joinsLetters || joinsDigits) && m > 0 {
+					i += n
+					continue
+				}
+				break
+			}
+			group = append(group, segment{text: text[start:i], start: start, end: i, kind: segWord})
+
+			// A single hyphen straight into another word continues the group.
+			if c, n := peek(i); c == classHyphen {
+				if next, _ := peek(i + n); isWordClass(next) {
+					i += n
+					continue
+				}
+			}
+			flush()
+
+		default:
+			flush()
+			i += size
+		}
+	}
+	flush()
+
+	return groups
+}
+
+// splitCase splits a word at case changes, the way the original ASCII
+// tokenizer did: "PageRank" -> "Page", "Rank"; "HTMLParser" -> "HTML",
+// "Parser". Digits stay with what precedes them.
+func splitCase(s segment) []segment {
+	runes := []rune(s.text)
+	var parts []segment
+
+	offset := s.start
+	partStart := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		split := unicode.IsLower(prev) && unicode.IsUpper(cur)
+		if !split && unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			split = true
+		}
+		if split {
+			text := string(runes[partStart:i])
+			parts = append(parts, segment{text: text, start: offset, end: offset + len(text), kind: segWord})
+			offset += len(text)
+			partStart = i
+		}
+	}
+	text := string(runes[partStart:])
+	parts = append(parts, segment{text: text, start: offset, end: offset + len(text), kind: segWord})
+
+	return parts
+}
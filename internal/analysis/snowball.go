@@ -0,0 +1,123 @@
+package analysis
+
+import "strings"
+
+// snowballWord is the working state shared by the Snowball stemmers: the
+// word as runes and the starts of the R1, R2 and RV regions.
+type snowballWord struct {
+	w          []rune
+	p1, p2, pV int
+	vowels     string
+}
+
+func newSnowballWord(word, vowels string) *snowballWord {
+	return &snowballWord{w: []rune(word), vowels: vowels}
+}
+
+func (s *snowballWord) String() string {
+	return string(s.w)
+}
+
+func (s *snowballWord) isVowel(i int) bool {
+	return i >= 0 && i < len(s.w) && strings.ContainsRune(s.vowels, s.w[i])
+}
+
+// markR1R2 sets R1 to the region after the first non-vowel following a
+// vowel, and R2 to the same taken again inside R1.
+func (s *snowballWord) markR1R2() {
+	s.p1 = s.afterVowelConsonant(0)
+	s.p2 = s.afterVowelConsonant(s.p1)
+}
+
+func (s *snowballWord) afterVowelConsonant(from int) int {
+	i := from
+	for i < len(s.w) && !s.isVowel(i) {
+		i++
+	}
+	for i < len(s.w) && s.isVowel(i) {
+		i++
+	}
+	if i < len(s.w) {
+		return i + 1
+	}
+	return len(s.w)
+}
+
+// longest returns the longest of suffixes the word ends with.
+func (s *snowballWord) longest(suffixes ...string) string {
+	best := ""
+	for _, suf := range suffixes {
+		if len(suf) > len(best) && s.endsWith(suf) {
+			best = suf
+		}
+	}
+	return best
+}
+
+// longestIn is longest restricted to suffixes starting inside region.
+func (s *snowballWord) longestIn(region int, suffixes ...string) string {
+	best := ""
+	for _, suf := range suffixes {
+		if len(suf) > len(best) && s.endsWith(suf) && s.in(region, suf) {
+			best = suf
+		}
+	}
+	return best
+}
+
+func (s *snowballWord) endsWith(suf string) bool {
+	r := []rune(suf)
+	if len(r) > len(s.w) {
+		return false
+	}
+	return string(s.w[len(s.w)-len(r):]) == suf
+}
+
+// start is the index where suf would begin if the word ended with it.
+func (s *snowballWord) start(suf string) int {
+	return len(s.w) - len([]rune(suf))
+}
+
+func (s *snowballWord) in(region int, suf string) bool {
+	return s.start(suf) >= region
+}
+
+func (s *snowballWord) delete(suf string) {
+	s.w = s.w[:s.start(suf)]
+}
+
+func (s *snowballWord) replace(suf, with string) {
+	s.w = append(s.w[:s.start(suf)], []rune(with)...)
+}
+
+// before reports whether prefix sits right before a suffix of n runes.
+func (s *snowballWord) before(n int, prefix string) bool {
+	r := []rune(prefix)
+	end := len(s.w) - n
+	if end-len(r) < 0 {
+		return false
+	}
+	return string(s.w[end-len(r):end]) == prefix
+}
+
+func (s *snowballWord) mapRunes(m map[rune]rune) {
+	for i, r := range s.w {
+		if to, ok := m[r]; ok {
+			s.w[i] = to
+		}
+	}
+}
+
+// markBetweenVowels upper-cases the runes in marks that sit between two
+// vowels, so the later steps treat them as consonants.
+func (s *snowballWord) markBetweenVowels(marks string) {
+	for i := 1; i+1 < len(s.w); i++ {
+		if strings.ContainsRune(marks, s.w[i]) && s.isVowel(i-1) && s.isVowel(i+1) {
+			s.w[i] = toMark(s.w[i])
+		}
+	}
+}
+
+func toMark(r rune) rune {
+	return r - 'a' + 'A'
+}
@@ -0,0 +1,359 @@
+package analysis
+
+import "strings"
+
+const frenchVowels = "aeiouyâàëéêèïîôûù"
+
+type frenchStemmer struct{}
+
+func (frenchStemmer) Language() string { return "french" }
+
+// Stem implements the Snowball French stemmer.
+func (frenchStemmer) Stem(word string) string {
+	s := newSnowballWord(word, frenchVowels)
+	s.frenchPrelude()
+	s.frenchRegions()
+
+	altered := false
+	suf, step1 := s.frenchStep1()
+	switch {
+	case step1 && suf != "amment" && suf != "emment" && suf != "ment" && suf != "ments":
+		altered = true
+	case s.frenchStep2a() || s.frenchStep2b():
+		altered = true
+	}
+
+	if altered {
+		// Step 3.
+		switch {
+		case s.endsWith("Y"):
+			s.replace("Y", "i")
+		case s.endsWith("ç"):
+			s.replace("ç", "c")
+		}
+	} else {
+		s.frenchStep4()
+	}
+
+	// Step 5: undouble.
+	if s.longest("enn", "onn", "ett", "ell", "eill") != "" {
+		s.w = s.w[:len(s.w)-1]
+	}
+
+	// Step 6: un-accent an é or è followed only by consonants.
+	i := len(s.w) - 1
+	for i >= 0 && !s.isVowel(i) {
+		i--
+	}
+	if i >= 0 && i < len(s.w)-1 && (s.w[i] == 'é' || s.w[i] == 'è') {
+		s.w[i] = 'e'
+	}
+
+	s.mapRunes(map[rune]rune{'I': 'i', 'U': 'u', 'Y': 'y'})
+	return s.String()
+}
+
+// frenchPrelude marks u and i between vowels, y next to a vowel, and u
+// after q as consonants.
+func (s *snowballWord) frenchPrelude() {
+	for i := range s.w {
+		switch s.w[i] {
+		case 'u':
+			if i > 0 && s.w[i-1] == 'q' {
+				s.w[i] = 'U'
+				continue
+			}
+			fallthrough
+		case 'i':
+			if s.isVowel(i-1) && s.isVowel(i+1) {
+				s.w[i] = toMark(s.w[i])
+			}
+		case 'y':
+			if s.isVowel(i-1) || s.isVowel(i+1) {
+				s.w[i] = 'Y'
+			}
+		}
+	}
+}
+
+func (s *snowballWord) frenchRegions() {
+	s.markR1R2()
+
+	n := len(s.w)
+	switch {
+	case n >= 2 && s.isVowel(0) && s.isVowel(1):
+		s.pV = min(3, n)
+	case strings.HasPrefix(string(s.w), "par"), strings.HasPrefix(string(s.w), "col"), strings.HasPrefix(string(s.w), "tap"):
+		s.pV = 3
+	default:
+		s.pV = n
+		for i := 1; i < n; i++ {
+			if s.isVowel(i) {
+				s.pV = i + 1
+				break
+			}
+		}
+	}
+}
+
+// frenchStep1 removes standard suffixes. It reports the suffix found and
+// whether the step succeeded; -ment endings are rewritten but count as a
+// failure so the verb steps still run.
+func (s *snowballWord) frenchStep1() (string, bool) {
+	suf := s.longest(
+		"ance", "iqUe", "isme", "able", "iste", "eux", "ances", "iqUes", "ismes", "ables", "istes",
+		"atrice", "ateur", "ation", "atrices", "ateurs", "ations",
+		"logie", "logies", "usion", "ution", "usions", "utions", "ence", "ences",
+		"ement", "ements", "ité", "ités", "if", "ive", "ifs", "ives",
+		"eaux", "aux", "euse", "euses", "issement", "issements",
+		"amment", "emment", "ment", "ments",
+	)
+
+	switch suf {
+	case "":
+		return suf, false
+
+	case "ance", "iqUe", "isme", "able", "iste", "eux", "ances", "iqUes", "ismes", "ables", "istes":
+		if !s.in(s.p2, suf) {
+			return suf, false
+		}
+		s.delete(suf)
+
+	case "atrice", "ateur", "ation", "atrices", "ateurs", "ations":
+		if !s.in(s.p2, suf) {
+			return suf, false
+		}
+		s.delete(suf)
+		if s.endsWith("ic") {
+			if s.in(s.p2, "ic") {
+				s.delete("ic")
+			} else {
+				s.replace("ic", "iqU")
+			}
+		}
+
+	case "logie", "logies":
+		if !s.in(s.p2, suf) {
+			return suf, false
+		}
+		s.replace(suf, "log")
+
+	case "usion", "ution", "usions", "utions":
+		if !s.in(s.p2, suf) {
+			return suf, false
+		}
+		s.replace(suf, "u")
+
+	case "ence", "ences":
+		if !s.in(s.p2, suf) {
+			return suf, false
+		}
+		s.replace(suf, "ent")
+
+	case "ement", "ements":
+		if !s.in(s.pV, suf) {
+			return suf, false
+		}
+		s.delete(suf)
+		switch pre := s.longest("iv", "eus", "abl", "iqU", "ièr", "Ièr"); pre {
+		case "iv":
+			if s.in(s.p2, pre) {
+				s.delete(pre)
+				if s.endsWith("at") && s.in(s.p2, "at") {
+					s.delete("at")
+				}
+			}
+		case "eus":
+			if s.in(s.p2, pre) {
+				s.delete(pre)
+			} else if s.in(s.p1, pre) {
+				s.replace(pre, "eux")
+			}
+		case "abl", "iqU":
+			if s.in(s.p2, pre) {
+				s.delete(pre)
+			}
+		case "ièr", "Ièr":
+			if s.in(s.pV, pre) {
+				s.replace(pre, "i")
+			}
+		}
+
+	case "ité", "ités":
+		if !s.in(s.p2, suf) {
+			return suf, false
+		}
+		s.delete(suf)
+		switch pre := s.longest("abil", "ic", "iv"); pre {
+		case "abil":
+			if s.in(s.p2, pre) {
+				s.delete(pre)
+			} else {
+				s.replace(pre, "abl")
+			}
+		case "ic":
+			if s.in(s.p2, pre) {
+				s.delete(pre)
+			} else {
+				s.replace(pre, "iqU")
+			}
+		case "iv":
+			if s.in(s.p2, pre) {
+				s.delete(pre)
+			}
+		}
+
+	case "if", "ive", "ifs", "ives":
+		if !s.in(s.p2, suf) {
+			return suf, false
+		}
+		s.delete(suf)
+		if s.endsWith("at") && s.in(s.p2, "at") {
+			s.delete("at")
+			if s.endsWith("ic") {
+				if s.in(s.p2, "ic") {
+					s.delete("ic")
+				} else {
+					s.replace("ic", "iqU")
+				}
+			}
+		}
+
+	case "eaux":
+		s.replace(suf, "eau")
+
+	case "aux":
+		if !s.in(s.p1, suf) {
+			return suf, false
+		}
+		s.replace(suf, "al")
+
+	case "euse", "euses":
+		switch {
+		case s.in(s.p2, suf):
+			s.delete(suf)
+		case s.in(s.p1, suf):
+			s.replace(suf, "eux")
+		default:
+			return suf, false
+		}
+
+	case "issement", "issements":
+		if !s.in(s.p1, suf) || s.isVowel(s.start(suf)-1) || s.start(suf) == 0 {
+			return suf, false
+		}
+		s.delete(suf)
+
+	case "amment":
+		if s.in(s.pV, suf) {
+			s.replace(suf, "ant")
+		}
+		return suf, false
+
+	case "emment":
+		if s.in(s.pV, suf) {
+			s.replace(suf, "ent")
+		}
+		return suf, false
+
+	case "ment", "ments":
+		if i := s.start(suf) - 1; i >= s.pV && s.isVowel(i) {
+			s.delete(suf)
+		}
+		return suf, false
+	}
+
+	return suf, true
+}
+
+var frenchIVerbSuffixes = []string{
+	"îmes", "ît", "îtes", "i", "ie", "ies", "ir", "ira", "irai", "iraIent", "irais", "irait", "iras",
+	"irent", "irez", "iriez", "irions", "irons", "iront", "is", "issaIent", "issais", "issait",
+	"issant", "issante", "issantes", "issants", "isse", "issent", "isses", "issez", "issiez",
+	"issions", "issons", "it",
+}
+
+// frenchStep2a removes verb endings in i preceded by a consonant in RV.
+func (s *snowballWord) frenchStep2a() bool {
+	suf := s.longestIn(s.pV, frenchIVerbSuffixes...)
+	if suf == "" {
+		return false
+	}
+	i := s.start(suf) - 1
+	if i < s.pV || s.isVowel(i) {
+		return false
+	}
+	s.delete(suf)
+	return true
+}
+
+var (
+	frenchVerbDelete = []string{
+		"é", "ée", "ées", "és", "èrent", "er", "era", "erai", "eraIent", "erais", "erait", "eras",
+		"erez", "eriez", "erions", "erons", "eront", "ez", "iez",
+	}
+	frenchVerbDeleteE = []string{
+		"âmes", "ât", "âtes", "a", "ai", "aIent", "ais", "ait", "ant", "ante", "antes", "ants", "as",
+		"asse", "assent", "asses", "assiez", "assions",
+	}
+)
+
+// frenchStep2b removes the other verb endings found in RV.
+func (s *snowballWord) frenchStep2b() bool {
+	all := append(append([]string{"ions"}, frenchVerbDelete...), frenchVerbDeleteE...)
+	suf := s.longestIn(s.pV, all...)
+	if suf == "" {
+		return false
+	}
+
+	switch {
+	case suf == "ions":
+		if !s.in(s.p2, suf) {
+			return false
+		}
+		s.delete(suf)
+	case contains(frenchVerbDelete, suf):
+		s.delete(suf)
+	default:
+		s.delete(suf)
+		if s.endsWith("e") && s.in(s.pV, "e") {
+			s.delete("e")
+		}
+	}
+	return true
+}
+
+// frenchStep4 removes residual suffixes when nothing else changed the word.
+func (s *snowballWord) frenchStep4() {
+	if s.endsWith("s") && len(s.w) > 1 && !strings.ContainsRune("aiouès", s.w[len(s.w)-2]) {
+		s.delete("s")
+	}
+
+	suf := s.longestIn(s.pV, "ion", "ier", "ière", "Ier", "Ière", "e", "ë")
+	if suf == "" {
+		return
+	}
+	switch suf {
+	case "ion":
+		if s.in(s.p2, suf) && s.start(suf) > s.pV && s.beforeAny(3, "st") {
+			s.delete(suf)
+		}
+	case "ier", "ière", "Ier", "Ière":
+		s.replace(suf, "i")
+	case "e":
+		s.delete(suf)
+	case "ë":
+		if s.before(1, "gu") {
+			s.delete(suf)
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
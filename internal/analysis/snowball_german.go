@@ -0,0 +1,82 @@
+package analysis
+
+import "strings"
+
+const germanVowels = "aeiouyäöü"
+
+type germanStemmer struct{}
+
+func (germanStemmer) Language() string { return "german" }
+
+// Stem implements the Snowball German stemmer.
+func (germanStemmer) Stem(word string) string {
+	s := newSnowballWord(strings.ReplaceAll(word, "ß", "ss"), germanVowels)
+	s.markBetweenVowels("uy")
+
+	s.markR1R2()
+	// R1 never starts before the fourth letter.
+	s.p1 = max(s.p1, min(3, len(s.w)))
+
+	// Step 1.
+	switch suf := s.longest("em", "ern", "er", "e", "en", "es", "s"); {
+	case suf == "":
+	case !s.in(s.p1, suf):
+	case suf == "s":
+		if s.beforeAny(1, "bdfghklmnrt") {
+			s.delete(suf)
+		}
+	case suf == "e" || suf == "en" || suf == "es":
+		s.delete(suf)
+		if s.endsWith("niss") {
+			s.delete("s")
+		}
+	default:
+		s.delete(suf)
+	}
+
+	// Step 2.
+	switch suf := s.longest("en", "er", "est", "st"); {
+	case suf == "":
+	case !s.in(s.p1, suf):
+	case suf == "st":
+		if s.beforeAny(2, "bdfghklmnt") && s.start(suf) >= 4 {
+			s.delete(suf)
+		}
+	default:
+		s.delete(suf)
+	}
+
+	// Step 3: derivational suffixes.
+	switch suf := s.longest("end", "ung", "ig", "ik", "isch", "lich", "heit", "keit"); {
+	case suf == "":
+	case !s.in(s.p2, suf):
+	case suf == "end" || suf == "ung":
+		s.delete(suf)
+		if s.endsWith("ig") && !s.before(2, "e") && s.in(s.p2, "ig") {
+			s.delete("ig")
+		}
+	case suf == "ig" || suf == "ik" || suf == "isch":
+		if !s.before(len([]rune(suf)), "e") {
+			s.delete(suf)
+		}
+	case suf == "lich" || suf == "heit":
+		s.delete(suf)
+		if pre := s.longest("er", "en"); pre != "" && s.in(s.p1, pre) {
+			s.delete(pre)
+		}
+	case suf == "keit":
+		s.delete(suf)
+		if pre := s.longest("lich", "ig"); pre != "" && s.in(s.p2, pre) {
+			s.delete(pre)
+		}
+	}
+
+	s.mapRunes(map[rune]rune{'U': 'u', 'Y': 'y', 'ä': 'a', 'ö': 'o', 'ü': 'u'})
+	return s.String()
+}
+
+// beforeAny reports whether the rune before a suffix of n runes is in set.
+func (s *snowballWord) beforeAny(n int, set string) bool {
+	i := len(s.w) - n - 1
+	return i >= 0 && strings.ContainsRune(set, s.w[i])
+}
@@ -0,0 +1,211 @@
+package analysis
+
+const spanishVowels = "aeiouáéíóúü"
+
+type spanishStemmer struct{}
+
+func (spanishStemmer) Language() string { return "spanish" }
+
+// Stem implements the Snowball Spanish stemmer.
+func (spanishStemmer) Stem(word string) string {
+	s := newSnowballWord(word, spanishVowels)
+	s.markR1R2()
+	s.spanishRV()
+
+	s.spanishAttachedPronoun()
+	if !s.spanishStandardSuffix() && !s.spanishYVerbSuffix() {
+		s.spanishVerbSuffix()
+	}
+	s.spanishResidualSuffix()
+
+	s.mapRunes(map[rune]rune{'á': 'a', 'é': 'e', 'í': 'i', 'ó': 'o', 'ú': 'u'})
+	return s.String()
+}
+
+// spanishRV starts RV after the next vowel when the second letter is a
+// consonant, after the next consonant when the word opens with two vowels,
+// and after the third letter otherwise.
+func (s *snowballWord) spanishRV() {
+	n := len(s.w)
+	s.pV = n
+	if n < 2 {
+		return
+	}
+	switch {
+	case !s.isVowel(1):
+		for i := 2; i < n; i++ {
+			if s.isVowel(i) {
+				s.pV = i + 1
+				return
+			}
+		}
+	case s.isVowel(0):
+		for i := 2; i < n; i++ {
+			if !s.isVowel(i) {
+				s.pV = i + 1
+				return
+			}
+		}
+	default:
+		s.pV = min(3, n)
+	}
+}
+
+var spanishPronouns = []string{
+	"me", "se", "sela", "selo", "selas", "selos", "la", "le", "lo", "las", "les", "los", "nos",
+}
+
+// spanishAttachedPronoun strips an enclitic pronoun from a gerund or
+// infinitive, dropping the accent it forced on the verb.
+func (s *snowballWord) spanishAttachedPronoun() {
+	pron := s.longest(spanishPronouns...)
+	if pron == "" {
+		return
+	}
+	verb := &snowballWord{w: s.w[:s.start(pron)], vowels: s.vowels}
+	form := verb.longest("iéndo", "ándo", "ár", "ér", "ír", "ando", "iendo", "ar", "er", "ir", "yendo")
+	if form == "" || !verb.in(s.pV, form) {
+		return
+	}
+
+	switch form {
+	case "yendo":
+		if !verb.before(len(form), "u") {
+			return
+		}
+	case "iéndo", "ándo", "ár", "ér", "ír":
+		i := verb.start(form)
+		for ; i < len(verb.w); i++ {
+			switch verb.w[i] {
+			case 'á':
+				verb.w[i] = 'a'
+			case 'é':
+				verb.w[i] = 'e'
+			case 'í':
+				verb.w[i] = 'i'
+			}
+		}
+	}
+	s.delete(pron)
+}
+
+func (s *snowballWord) spanishStandardSuffix() bool {
+	suf := s.longest(
+		"anza", "anzas", "ico", "ica", "icos", "icas", "ismo", "ismos", "able", "ables", "ible", "ibles",
+		"ista", "istas", "oso", "osa", "osos", "osas", "amiento", "amientos", "imiento", "imientos",
+		"adora", "ador", "ación", "adoras", "adores", "aciones", "ante", "antes", "ancia", "ancias",
+		"logía", "logías", "ución", "uciones", "encia", "encias", "amente", "mente",
+		"idad", "idades", "iva", "ivo", "ivas", "ivos",
+	)
+
+	switch suf {
+	case "":
+		return false
+
+	case "amente":
+		if !s.in(s.p1, suf) {
+			return false
+		}
+		s.delete(suf)
+		switch pre := s.longest("iv", "os", "ic", "ad"); pre {
+		case "":
+		case "iv":
+			if s.in(s.p2, pre) {
+				s.delete(pre)
+				if s.endsWith("at") && s.in(s.p2, "at") {
+					s.delete("at")
+				}
+			}
+		default:
+			if s.in(s.p2, pre) {
+				s.delete(pre)
+			}
+		}
+		return true
+	}
+
+	if !s.in(s.p2, suf) {
+		return false
+	}
+	switch suf {
+	case "adora", "ador", "ación", "adoras", "adores", "aciones", "ante", "antes", "ancia", "ancias":
+		s.delete(suf)
+		if s.endsWith("ic") && s.in(s.p2, "ic") {
+			s.delete("ic")
+		}
+	case "logía", "logías":
+		s.replace(suf, "log")
+	case "ución", "uciones":
+		s.replace(suf, "u")
+	case "encia", "encias":
+		s.replace(suf, "ente")
+	case "mente":
+		s.delete(suf)
+		if pre := s.longest("ante", "able", "ible"); pre != "" && s.in(s.p2, pre) {
+			s.delete(pre)
+		}
+	case "idad", "idades":
+		s.delete(suf)
+		if pre := s.longest("abil", "ic", "iv"); pre != "" && s.in(s.p2, pre) {
+			s.delete(pre)
+		}
+	case "iva", "ivo", "ivas", "ivos":
+		s.delete(suf)
+		if s.endsWith("at") && s.in(s.p2, "at") {
+			s.delete("at")
+		}
+	default:
+		s.delete(suf)
+	}
+	return true
+}
+
+// spanishYVerbSuffix removes y- verb endings that follow a u.
+func (s *snowballWord) spanishYVerbSuffix() bool {
+	suf := s.longestIn(s.pV, "ya", "ye", "yan", "yen", "yeron", "yendo", "yo", "yó", "yas", "yes", "yais", "yamos")
+	if suf == "" || !s.before(len([]rune(suf)), "u") {
+		return false
+	}
+	s.delete(suf)
+	return true
+}
+
+var spanishVerbSuffixes = []string{
+	"arían", "arías", "arán", "arás", "aríais", "aría", "aréis", "aríamos", "aremos", "ará", "aré",
+	"erían", "erías", "erán", "erás", "eríais", "ería", "eréis", "eríamos", "eremos", "erá", "eré",
+	"irían", "irías", "irán", "irás", "iríais", "iría", "iréis", "iríamos", "iremos", "irá", "iré",
+	"aba", "ada", "ida", "ía", "ara", "iera", "ad", "ed", "id", "ase", "iese", "aste", "iste", "an",
+	"aban", "ían", "aran", "ieran", "asen", "iesen", "aron", "ieron", "ado", "ido", "ando", "iendo",
+	"ió", "ar", "er", "ir", "as", "abas", "adas", "idas", "ías", "aras", "ieras", "ases", "ieses",
+	"ís", "áis", "abais", "íais", "arais", "ierais", "aseis", "ieseis", "asteis", "isteis", "ados",
+	"idos", "amos", "ábamos", "íamos", "imos", "áramos", "iéramos", "iésemos", "ásemos",
+	"en", "es", "éis", "emos",
+}
+
+func (s *snowballWord) spanishVerbSuffix() {
+	suf := s.longestIn(s.pV, spanishVerbSuffixes...)
+	if suf == "" {
+		return
+	}
+	s.delete(suf)
+	switch suf {
+	case "en", "es", "éis", "emos":
+		// A gu before these endings keeps only the g.
+		if s.endsWith("gu") {
+			s.delete("u")
+		}
+	}
+}
+
+func (s *snowballWord) spanishResidualSuffix() {
+	switch suf := s.longestIn(s.pV, "os", "a", "o", "á", "í", "ó", "e", "é"); suf {
+	case "":
+	case "e", "é":
+		s.delete(suf)
+		if s.endsWith("gu") && s.in(s.pV, "u") {
+			s.delete("u")
+		}
+	default:
+		s.delete(suf)
+	}
+}
@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// StaticEmbedder serves pre-trained word vectors (GloVe / word2vec text format)
+// from memory. A text is embedded as the mean of its known word vectors.
+type StaticEmbedder struct {
+	name    string
+	dims    int
+	vectors map[string][]float32
+}
+
+// LoadStaticEmbedder reads a "word v1 v2 ... vn" file. A word2vec style
+// "<count> <dims>" header line is skipped if present. The model ID carries a
+// hash of the contents, so two files with the same name do not pass for the
+// same model.
+func LoadStaticEmbedder(path string) (*StaticEmbedder, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	s := &StaticEmbedder{
+		vectors: make(map[string][]float32),
+	}
+
+	hash := sha256.New()
+	scanner := bufio.NewScanner(io.TeeReader(file, hash))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if line == 1 && len(fields) == 2 {
+			if _, err := strconv.Atoi(fields[0]); err == nil {
+				continue
+			}
+		}
+
+		vec := make([]float32, len(fields)-1)
+		for i, f := range fields[1:] {
+			v, err := strconv.ParseFloat(f, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: bad component %q: %w", path, line, f, err)
+			}
+			vec[i] = float32(v)
+		}
+
+		if s.dims == 0 {
+			s.dims = len(vec)
+		} else if len(vec) != s.dims {
+			return nil, fmt.Errorf("%s:%d: expected %d dimensions, got %d", path, line, s.dims, len(vec))
+		}
+
+		s.vectors[strings.ToLower(fields[0])] = vec
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if s.dims == 0 {
+		return nil, fmt.Errorf("%s: no word vectors found", path)
+	}
+
+	s.name = "static:" + filepath.Base(path) + "@" + hex.EncodeToString(hash.Sum(nil))[:16]
+	return s, nil
+}
+
+func (s *StaticEmbedder) ModelID() string {
+	return s.name
+}
+
+func (s *StaticEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	out := make([]float32, s.dims)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	known := 0
+	for _, w := range words {
+		vec, ok := s.vectors[w]
+		if !ok {
+			continue
+		}
+		for i, v := range vec {
+			out[i] += v
+		}
+		known++
+	}
+
+	// Unknown text maps to the zero vector, which has no similarity to anything.
+	if known > 1 {
+		for i := range out {
+			out[i] /= float32(known)
+		}
+	}
+
+	return out, nil
+}
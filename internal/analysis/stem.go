@@ -0,0 +1,112 @@
+package analysis
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// LanguageStemmer reduces the words of one language to their stems. Input
+// is expected lowercased and with its accents intact.
+type LanguageStemmer interface {
+	Language() string
+	Stem(word string) string
+}
+
+type englishStemmer struct{}
+
+func (englishStemmer) Language() string { return "english" }
+
+func (englishStemmer) Stem(word string) string {
+	if !isASCII(word) {
+		return word
+	}
+	return porter.Stem(word)
+}
+
+var stemmers = map[string]LanguageStemmer{
+	"english": englishStemmer{},
+	"german":  germanStemmer{},
+	"french":  frenchStemmer{},
+	"spanish": spanishStemmer{},
+}
+
+var languageCodes = map[string]string{
+	"en": "english",
+	"de": "german",
+	"fr": "french",
+	"es": "spanish",
+}
+
+// CanonicalLanguage maps a language name or ISO 639-1 code to the name the
+// stemmers and language analyzers are registered under.
+func CanonicalLanguage(lang string) (string, error) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if name, ok := languageCodes[lang]; ok {
+		return name, nil
+	}
+	if _, ok := stemmers[lang]; ok {
+		return lang, nil
+	}
+	return "", fmt.Errorf("unsupported language %q", lang)
+}
+
+// Languages returns the canonical names of the supported languages, sorted.
+func Languages() []string {
+	return slices.Sorted(maps.Keys(stemmers))
+}
+
+func StemmerFor(lang string) (LanguageStemmer, error) {
+	name, err := CanonicalLanguage(lang)
+	if err != nil {
+		return nil, err
+	}
+	return stemmers[name], nil
+}
+
+type stemFilter struct {
+	stemmer LanguageStemmer
+	keep    bool
+}
+
+// NewStemFilter stems every non-Keyword token. With keepOriginal the
+// unstemmed word is emitted at the same position too, so exact forms score
+// above other words sharing the stem.
+func NewStemFilter(stemmer LanguageStemmer, keepOriginal bool) TokenFilter {
+	return &stemFilter{stemmer: stemmer, keep: keepOriginal}
+}
+
+func (f *stemFilter) Filter(tokens []Token) []Token {
+	var out []Token
+	for _, t := range tokens {
+		if t.Keyword {
+			out = append(out, t)
+			continue
+		}
+		stem := f.stemmer.Stem(t.Term)
+		if f.keep && stem != t.Term {
+			out = append(out, t)
+		}
+		if stem != "" {
+			t.Term = stem
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// StopWords are the stop lists of the language analyzers, lowercased and
+// with accents, as they look before folding.
+var StopWords = map[string][]string{
+	"english": EnglishStopWords,
+	"german": {
+		"aber", "alle", "allem", "allen", "aller", "alles", "als", "also", "am", "an", "ander", "andere", "anderem", "anderen", "anderer", "anderes", "auch", "auf", "aus", "bei", "bin", "bis", "bist", "da", "damit", "dann", "das", "dass", "dein", "deine", "dem", "den", "denn", "der", "des", "dich", "die", "dies", "diese", "diesem", "diesen", "dieser", "dieses", "dir", "doch", "dort", "du", "durch", "ein", "eine", "einem", "einen", "einer", "eines", "er", "es", "etwas", "euch", "euer", "für", "gegen", "hat", "hatte", "hier", "hin", "ich", "ihm", "ihn", "ihr", "ihre", "im", "in", "ist", "jede", "jedem", "jeden", "jeder", "jetzt", "kann", "kein", "keine", "man", "mein", "meine", "mich", "mir", "mit", "nach", "nicht", "nichts", "noch", "nun", "nur", "ob", "oder", "ohne", "sehr", "sein", "seine", "sich", "sie", "sind", "so", "solche", "um", "und", "uns", "unser", "unter", "viel", "vom", "von", "vor", "war", "waren", "was", "weil", "welche", "wenn", "werden", "wie", "wieder", "wir", "wird", "zu", "zum", "zur", "über",
+	},
+	"french": {
+		"au", "aux", "avec", "ce", "ces", "dans", "de", "des", "du", "elle", "en", "et", "eux", "il", "ils", "je", "la", "le", "les", "leur", "lui", "ma", "mais", "me", "même", "mes", "moi", "mon", "ne", "nos", "notre", "nous", "on", "ou", "par", "pas", "pour", "qu", "que", "qui", "sa", "se", "ses", "son", "sur", "ta", "te", "tes", "toi", "ton", "tu", "un", "une", "vos", "votre", "vous", "été", "est", "sont", "était", "être", "avoir", "ont", "a", "c", "d", "j", "l", "m", "n", "s", "t", "y",
+	},
+	"spanish": {
+		"a", "al", "algo", "algunos", "ante", "antes", "como", "con", "contra", "cual", "cuando", "de", "del", "desde", "donde", "durante", "e", "el", "ella", "ellas", "ellos", "en", "entre", "era", "es", "esa", "esas", "ese", "eso", "esos", "esta", "estas", "este", "esto", "estos", "está", "fue", "ha", "hay", "la", "las", "le", "les", "lo", "los", "me", "mi", "mis", "mucho", "muy", "más", "nada", "ni", "no", "nos", "nosotros", "o", "otra", "otros", "para", "pero", "poco", "por", "porque", "que", "quien", "qué", "se", "sea", "ser", "si", "sin", "sobre", "su", "sus", "también", "te", "tiene", "todo", "todos", "tu", "tus", "un", "una", "uno", "unos", "y", "ya", "yo", "él",
+	},
+}
@@ -0,0 +1,413 @@
+package analysis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// SynonymRule maps each phrase in Input to every phrase in Output. An
+// equivalence rule ("tv, television") lists the same phrases on both sides
+// and keeps the original; an explicit mapping ("tv => television") replaces
+// it unless the input is also listed as an output.
+type SynonymRule struct {
+	Input  []string
+	Output []string
+}
+
+// ParseSolrSynonyms reads the Solr synonyms.txt format: one rule per line,
+// either "a, b, c" or "a, b => c, d", with # comments.
+func ParseSolrSynonyms(r io.Reader) ([]SynonymRule, error) {
+	var rules []SynonymRule
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		lhs, rhs, explicit := strings.Cut(text, "=>")
+		input := splitPhrases(lhs)
+		if len(input) == 0 {
+			return nil, fmt.Errorf("line %d: rule has no input", line)
+		}
+		output := input
+		if explicit {
+			if output = splitPhrases(rhs); len(output) == 0 {
+				return nil, fmt.Errorf("line %d: rule has no output", line)
+			}
+		}
+		rules = append(rules, SynonymRule{Input: input, Output: output})
+	}
+	return rules, scanner.Err()
+}
+
+func splitPhrases(s string) []string {
+	var phrases []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			phrases = append(phrases, p)
+		}
+	}
+	return phrases
+}
+
+var wordNetLine = regexp.MustCompile(`^s\((\d+),\d+,'((?:[^']|'')*)',`)
+
+// ParseWordNetSynonyms reads the prolog wn_s.pl file; the words of each
+// synset become one equivalence rule.
+func ParseWordNetSynonyms(r io.Reader) ([]SynonymRule, error) {
+	var rules []SynonymRule
+	var synset string
+	var words []string
+
+	flush := func() {
+		if len(words) > 1 {
+			rules = append(rules, SynonymRule{Input: words, Output: words})
+		}
+		words = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := wordNetLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		if m[1] != synset {
+			flush()
+			synset = m[1]
+		}
+		words = append(words, strings.ReplaceAll(strings.ReplaceAll(m[2], "''", "'"), "_", " "))
+	}
+	flush()
+
+	return rules, scanner.Err()
+}
+
+// SynonymSet holds rules that can be swapped at run time. Filters built on
+// it pick up a reload on their next use, so query-time synonyms change
+// without reindexing.
+type SynonymSet struct {
+	path    string
+	format  string
+	rules   atomic.Pointer[[]SynonymRule]
+	version atomic.Uint64
+}
+
+func NewSynonymSet(rules []SynonymRule) *SynonymSet {
+	s := &SynonymSet{}
+	s.store(rules)
+	return s
+}
+
+var (
+	synonymFilesMu sync.Mutex
+	synonymFiles   = make(map[string]*SynonymSet)
+)
+
+// LoadSynonymFile reads a "solr" or "wordnet" file. Sets are shared by path,
+// so every analyzer naming a file sees the same rules.
+func LoadSynonymFile(path, format string) (*SynonymSet, error) {
+	synonymFilesMu.Lock()
+	defer synonymFilesMu.Unlock()
+
+	if s, ok := synonymFiles[path]; ok {
+		if s.format != format {
+			return nil, fmt.Errorf("synonym file %s is already loaded as %s", path, s.format)
+		}
+		return s, nil
+	}
+
+	s := &SynonymSet{path: path, format: format}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	synonymFiles[path] = s
+	return s, nil
+}
+
+// ReloadSynonymFiles rereads every file loaded so far. A file that fails to
+// parse keeps its previous rules.
+func ReloadSynonymFiles() error {
+	synonymFilesMu.Lock()
+	defer synonymFilesMu.Unlock()
+
+	var errs []string
+	for _, s := range synonymFiles {
+		if err := s.Reload(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("reload synonyms: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Reload rereads the file the set was loaded from.
+func (s *SynonymSet) Reload() error {
+	if s.path == "" {
+		return nil
+	}
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var rules []SynonymRule
+	switch s.format {
+	case "", "solr":
+		rules, err = ParseSolrSynonyms(f)
+	case "wordnet":
+		rules, err = ParseWordNetSynonyms(f)
+	default:
+		return fmt.Errorf("unknown synonym format %q", s.format)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.path, err)
+	}
+
+	s.store(rules)
+	return nil
+}
+
+func (s *SynonymSet) store(rules []SynonymRule) {
+	s.rules.Store(&rules)
+	s.version.Add(1)
+}
+
+func (s *SynonymSet) Rules() []SynonymRule {
+	return *s.rules.Load()
+}
+
+// synonymEntry is every rewrite of one input phrase.
+type synonymEntry struct {
+	input        []string
+	outputs      [][]string
+	keepOriginal bool
+}
+
+// synonymMap indexes analyzed rules by the first term of their input.
+type synonymMap map[string][]*synonymEntry
+
+func buildSynonymMap(rules []SynonymRule, analyze func(string) []string) synonymMap {
+	m := make(synonymMap)
+	byInput := make(map[string]*synonymEntry)
+
+	for _, rule := range rules {
+		var outputs [][]string
+		for _, phrase := range rule.Output {
+			if terms := analyze(phrase); len(terms) > 0 {
+				outputs = append(outputs, terms)
+			}
+		}
+
+		for _, phrase := range rule.Input {
+			input := analyze(phrase)
+			if len(input) == 0 {
+				continue
+			}
+			key := strings.Join(input, " ")
+			e, ok := byInput[key]
+			if !ok {
+				e = &synonymEntry{input: input}
+				byInput[key] = e
+				m[input[0]] = append(m[input[0]], e)
+			}
+			for _, out := range outputs {
+				if strings.Join(out, " ") == key {
+					e.keepOriginal = true
+					continue
+				}
+				if !containsPhrase(e.outputs, out) {
+					e.outputs = append(e.outputs, out)
+				}
+			}
+		}
+	}
+	return m
+}
+
+func containsPhrase(phrases [][]string, p []string) bool {
+	for _, q := range phrases {
+		if strings.Join(q, " ") == strings.Join(p, " ") {
+			return true
+		}
+	}
+	return false
+}
+
+// longest returns the entry with the longest input matching words from i.
+func (m synonymMap) longest(words []Token, i int) *synonymEntry {
+	var best *synonymEntry
+	for _, e := range m[words[i].Term] {
+		if i+len(e.input) > len(words) || (best != nil && len(e.input) <= len(best.input)) {
+			continue
+		}
+		match := true
+		for k, term := range e.input {
+			if words[i+k].Term != term {
+				match = false
+				break
+			}
+		}
+		if match {
+			best = e
+		}
+	}
+	return best
+}
+
+type synonymGraphFilter struct {
+	set   *SynonymSet
+	chain *Analyzer
+
+	mu      sync.Mutex
+	version uint64
+	m       synonymMap
+}
+
+// NewSynonymGraphFilter expands matches of set into a token graph: every
+// alternative phrase runs in parallel from the first to the last position
+// of the match, with PositionLength stretching the shorter paths, so
+// "ny => new york" still lines up with the words around it. Rule phrases are
+// analyzed with chain, which should be the analyzer stages before this
+// filter; without one they are split on spaces and lower-cased.
+//
+// Multi-word outputs only make sense at query time; at index time the graph
+// is flattened.
+func NewSynonymGraphFilter(set *SynonymSet, chain *Analyzer) TokenFilter {
+	return &synonymGraphFilter{set: set, chain: chain}
+}
+
+func (f *synonymGraphFilter) useChain(chain *Analyzer) {
+	f.chain = chain
+}
+
+func (f *synonymGraphFilter) current() synonymMap {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if v := f.set.version.Load(); f.m == nil || v != f.version {
+		f.m = buildSynonymMap(f.set.Rules(), f.analyzePhrase)
+		f.version = v
+	}
+	return f.m
+}
+
+func (f *synonymGraphFilter) analyzePhrase(phrase string) []string {
+	if f.chain == nil {
+		return strings.Fields(strings.ToLower(phrase))
+	}
+	var terms []string
+	for _, t := range f.chain.Analyze(phrase) {
+		if !t.Keyword {
+			terms = append(terms, t.Term)
+		}
+	}
+	return terms
+}
+
+func (f *synonymGraphFilter) Filter(tokens []Token) []Token {
+	m := f.current()
+	if len(m) == 0 {
+		return tokens
+	}
+
+	// Rules match over the plain words; compounds and URLs ride along.
+	var words []Token
+	var wordIdx []int
+	for i, t := range tokens {
+		if !t.Keyword {
+			words = append(words, t)
+			wordIdx = append(wordIdx, i)
+		}
+	}
+
+	var inserts []insertion
+	dropped := make(map[int]bool)  // by index into tokens
+	stretched := make(map[int]int) // by index into tokens
+	var expanded []Token
+
+	for i := 0; i < len(words); {
+		e := m.longest(words, i)
+		if e == nil {
+			i++
+			continue
+		}
+
+		matched := words[i : i+len(e.input)]
+		first, last := matched[0], matched[len(matched)-1]
+		end := last.Position + last.Span()
+		span := end - first.Position
+
+		longest := span
+		for _, o := range e.outputs {
+			longest = max(longest, len(o))
+		}
+		start := first.Position + shiftFor(inserts, first.Position)
+		for _, o := range e.outputs {
+			for k, term := range o {
+				t := Token{Term: term, Start: first.Start, End: last.End, Position: start + k, Synonym: true}
+				if k == len(o)-1 {
+					t.PositionLength = longest - k
+				}
+				expanded = append(expanded, t)
+			}
+		}
+
+		for k := range matched {
+			if !e.keepOriginal {
+				dropped[wordIdx[i+k]] = true
+			}
+		}
+		if extra := longest - span; extra > 0 {
+			// Stretch the original path to meet the longer alternative.
+			stretched[wordIdx[i+len(matched)-1]] = extra
+			inserts = append(inserts, insertion{at: end, extra: extra})
+		}
+		i += len(e.input)
+	}
+
+	out := make([]Token, 0, len(tokens)+len(expanded))
+	for i, t := range tokens {
+		if dropped[i] {
+			continue
+		}
+		if extra := stretched[i]; extra > 0 {
+			t.PositionLength = t.Span() + extra
+		}
+		t.Position += shiftFor(inserts, t.Position)
+		out = append(out, t)
+	}
+	return append(out, expanded...)
+}
+
+// insertion records extra positions opened up from original position at on,
+// to make room for an alternative longer than what it replaces.
+type insertion struct{ at, extra int }
+
+func shiftFor(inserts []insertion, pos int) int {
+	shift := 0
+	for _, ins := range inserts {
+		if pos >= ins.at {
+			shift += ins.extra
+		}
+	}
+	return shift
+}
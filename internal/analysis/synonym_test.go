@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// graph renders tokens as term@position+span, sorted, so tests do not
+// depend on the order filters append in.
+func graph(tokens []Token) []string {
+	out := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		out = append(out, fmt.Sprintf("%s@%d+%d", t.Term, t.Position, t.Span()))
+	}
+	slices.Sort(out)
+	return out
+}
+
+func TestSynonymGraphPositions(t *testing.T) {
+	tests := []struct {
+		rules string
+		text  string
+		want  []string
+	}{
+		{"ny => new york", "ny pizza", []string{"new@0+1", "pizza@2+1", "york@1+1"}},
+		{"new york => ny", "new york city", []string{"city@2+1", "ny@0+2"}},
+		{"tv, television", "my tv", []string{"my@0+1", "television@1+1", "tv@1+1"}},
+		{"ny, new york", "ny pizza", []string{"new@0+1", "ny@0+2", "pizza@2+1", "york@1+1"}},
+		{"ny => new york", "ny ny", []string{"new@0+1", "new@2+1", "york@1+1", "york@3+1"}},
+		{"tv => television", "radio", []string{"radio@0+1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rules+" | "+tt.text, func(t *testing.T) {
+			rules, err := ParseSolrSynonyms(strings.NewReader(tt.rules))
+			if err != nil {
+				t.Fatal(err)
+			}
+			filter := NewSynonymGraphFilter(NewSynonymSet(rules), nil)
+			got := graph(filter.Filter(WhitespaceSegmenter{}.Segment(tt.text)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSolrSynonyms(t *testing.T) {
+	rules, err := ParseSolrSynonyms(strings.NewReader("# comment\ntv, television\n\nny => new  york # trailing\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SynonymRule{
+		{Input: []string{"tv", "television"}, Output: []string{"tv", "television"}},
+		{Input: []string{"ny"}, Output: []string{"new york"}},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("got %d rules, want %d", len(rules), len(want))
+	}
+	for i := range want {
+		if !slices.Equal(rules[i].Input, want[i].Input) || !slices.Equal(rules[i].Output, want[i].Output) {
+			t.Errorf("rule %d: got %+v, want %+v", i, rules[i], want[i])
+		}
+	}
+
+	if _, err := ParseSolrSynonyms(strings.NewReader("tv =>")); err == nil {
+		t.Error("rule without output accepted")
+	}
+}
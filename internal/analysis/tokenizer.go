@@ -1,49 +1,261 @@
 package analysis
 
-import (
-	"regexp"
-	"strings"
-)
+import "strings"
 
 type Tokenizer interface {
 	Tokenize(text string) []string
 }
 
+// Segmenter is the tokenizer stage of an Analyzer: it cuts text into tokens
+// with offsets and positions, leaving normalization to the token filters.
+type Segmenter interface {
+	Segment(text string) []Token
+}
+
+// Token is one analyzed term together with where it came from, so callers
+// can map matches back onto the original text.
+type Token struct {
+	Term     string // the normalized form that gets indexed
+	Start    int    // byte offset of the first byte in the original text
+	End      int    // byte offset just past the last byte
+	Position int    // ordinal of the token in the stream, stop words included
+	Keyword  bool   // a whole URL, email or compound; not stemmed or stopped
+	Synonym  bool   // added by a synonym rule rather than read from the text
+
+	// PositionLength is how many positions the token spans in the token
+	// graph; 0 means 1. A compound spans its parts, so either path through
+	// the graph reads the same text.
+	PositionLength int
+}
+
+// Span is PositionLength with the zero value resolved.
+func (t Token) Span() int {
+	return max(t.PositionLength, 1)
+}
+
+// Apostrophe prefixes elided before a vowel in French and Italian, as in
+// "l'amour" or "qu'il".
+var elisions = map[string]bool{
+	"l": true, "d": true, "j": true, "m": true, "n": true, "s": true, "t": true, "c": true, "qu": true,
+}
+
+// StandardTokenizer is the "standard" analyzer: Unicode segmentation,
+// lower-casing, accent folding, English stop words and Porter stemming.
 type StandardTokenizer struct {
-	stopWords map[string]struct{}
+	cjkBigrams bool
+	fold       bool
+	analyzer   *Analyzer
 }
 
-func NewStandardTokenizer() *StandardTokenizer {
-	stopList := []string{
-		"a", "about", "above", "after", "again", "against", "all", "am", "an", "and", "any", "are", "as", "at", "be", "because", "been", "before", "being", "below", "between", "both", "but", "by", "can", "did", "do", "does", "doing", "don", "down", "during", "each", "few", "for", "from", "further", "had", "has", "have", "having", "he", "her", "here", "hers", "herself", "him", "himself", "his", "how", "i", "if", "in", "into", "is", "it", "its", "itself", "just", "me", "more", "most", "my", "myself", "no", "nor", "not", "now", "of", "off", "on", "once", "only", "or", "other", "our", "ours", "ourselves", "out", "over", "own", "s", "same", "she", "should", "so", "some", "such", "t", "than", "that", "the", "their", "theirs", "them", "themselves", "then", "there", "these", "they", "this", "those", "through", "to", "too", "under", "until", "up", "very", "was", "we", "were", "what", "when", "where", "which", "while", "who", "whom", "why", "will", "with", "you", "your", "yours", "yourself", "yourselves",
+type TokenizerOption func(*StandardTokenizer)
+
+// WithCJKBigrams controls how runs of Han, Hiragana and Katakana are split.
+// With bigrams (the default) "東京都" yields "東京" and "京都"; without, every
+// character is its own token.
+func WithCJKBigrams(enabled bool) TokenizerOption {
+	return func(t *StandardTokenizer) {
+		t.cjkBigrams = enabled
 	}
+}
 
-	stopMap := make(map[string]struct{})
-	for _, s := range stopList {
-		stopMap[s] = struct{}{}
+// WithFolding controls whether accents are stripped, so "café" and "cafe"
+// index the same. It is on by default.
+func WithFolding(enabled bool) TokenizerOption {
+	return func(t *StandardTokenizer) {
+		t.fold = enabled
 	}
-	return &StandardTokenizer{stopWords: stopMap}
+}
+
+func NewStandardTokenizer(opts ...TokenizerOption) *StandardTokenizer {
+	t := &StandardTokenizer{cjkBigrams: true, fold: true}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	filters := []TokenFilter{LowercaseFilter}
+	if t.fold {
+		filters = append(filters, ASCIIFoldingFilter)
+	}
+	filters = append(filters, NewStopFilter(EnglishStopWords), PorterStemFilter)
+
+	t.analyzer = &Analyzer{
+		Name:      "standard",
+		Tokenizer: &UnicodeSegmenter{CJKBigrams: t.cjkBigrams},
+		Filters:   filters,
+	}
+	return t
 }
 
 func (t *StandardTokenizer) Tokenize(text string) []string {
-	PorterStem := New()
+	return t.analyzer.Tokenize(text)
+}
 
-	re := regexp.MustCompile(`[A-Z][a-z0-9]*|[a-z0-9]+|[A-Z]+`)
-	rawTokens := re.FindAllString(text, -1)
+// Analyze is Tokenize with offsets and positions kept.
+func (t *StandardTokenizer) Analyze(text string) []Token {
+	return t.analyzer.Analyze(text)
+}
 
-	var filtered []string
+// UnicodeSegmenter splits text on Unicode word boundaries and leaves terms as
+// written, apart from dropping apostrophes.
+//
+// Compound forms are kept both whole and in parts: "state-of-the-art" also
+// yields "stateoftheart", "PageRank" also yields "PageRank" next to "Page"
+// and "Rank", and a URL or email address is kept whole next to the words
+// inside it. The whole form shares the position of its first part so phrase
+// matching still lines up, and is marked Keyword so filters leave it be.
+type UnicodeSegmenter struct {
+	CJKBigrams bool
+}
+
+func (u *UnicodeSegmenter) Segment(text string) []Token {
+	var tokens []Token
+	pos := 0
+
+	for _, group := range segmentText(text) {
+		first := group[0]
+		switch first.kind {
+		case segURL, segEmail:
+			whole := len(tokens)
+			tokens = append(tokens, Token{Term: first.text, Start: first.start, End: first.end, Position: pos, Keyword: true})
+			for _, inner := range segmentWords(text, first.start, first.end) {
+				for _, word := range splitDots(inner) {
+					tokens, pos = words(tokens, []segment{word}, pos)
+				}
+			}
+			if pos == tokens[whole].Position {
+				pos++
+			}
+			tokens[whole].PositionLength = pos - tokens[whole].Position
+		case segCJK:
+			tokens, pos = u.cjk(tokens, first, pos)
+		default:
+			tokens, pos = words(tokens, group, pos)
+		}
+	}
+
+	return tokens
+}
 
-	for _, token := range rawTokens {
-		token = strings.ToLower(token)
+// words emits a hyphen group: every part split further at case changes,
+// then the joined form if there was more than one part.
+func words(tokens []Token, group []segment, pos int) ([]Token, int) {
+	firstPos := pos
+	var joined strings.Builder
+	count := 0
+	start, end := -1, 0
 
-		if _, ok := t.stopWords[token]; ok {
+	for _, word := range group {
+		trimmed := trimApostrophes(word)
+		if trimmed.text == "" {
 			continue
 		}
+		// Offsets no longer line up once an inner apostrophe is gone.
+		parts := []segment{trimmed}
+		if len(trimmed.text) == trimmed.end-trimmed.start {
+			parts = splitCase(trimmed)
+		}
+		if start < 0 {
+			start = trimmed.start
+		}
+		end = trimmed.end
+		for _, part := range parts {
+			joined.WriteString(part.text)
+			count++
+			tokens = append(tokens, Token{Term: part.text, Start: part.start, End: part.end, Position: pos})
+			pos++
+		}
+	}
+
+	if count > 1 {
+		tokens = append(tokens, Token{Term: joined.String(), Start: start, End: end, Position: firstPos, Keyword: true, PositionLength: pos - firstPos})
+	}
+
+	return tokens, pos
+}
 
-		stemmed := PorterStem.Stem(token)
-		if stemmed != "" {
-			filtered = append(filtered, stemmed)
+// cjk emits overlapping bigrams over a run of CJK characters, or one token
+// per character when bigrams are off. A lone character is always a unigram.
+func (u *UnicodeSegmenter) cjk(tokens []Token, run segment, pos int) ([]Token, int) {
+	var starts []int
+	for i, r := range run.text {
+		if classify(r) == classCJK {
+			starts = append(starts, run.start+i)
 		}
 	}
-	return filtered
+	starts = append(starts, run.end)
+	chars := len(starts) - 1
+
+	width := 1
+	if u.CJKBigrams && chars > 1 {
+		width = 2
+	}
+	for i := 0; i+width <= chars; i++ {
+		start, end := starts[i], starts[i+width]
+		term := run.text[start-run.start : end-run.start]
+		tokens = append(tokens, Token{Term: term, Start: start, End: end, Position: pos})
+		pos++
+	}
+
+	return tokens, pos
+}
+
+// splitDots breaks "example.com" into its labels. Inside running text a dot
+// between letters is kept ("e.g."), but host names read better apart.
+func splitDots(group []segment) []segment {
+	var out []segment
+	for _, s := range group {
+		start := 0
+		for i := 0; i <= len(s.text); i++ {
+			if i < len(s.text) && s.text[i] != '.' {
+				continue
+			}
+			if i > start {
+				out = append(out, segment{text: s.text[start:i], start: s.start + start, end: s.start + i, kind: s.kind})
+			}
+			start = i + 1
+		}
+	}
+	return out
+}
+
+// trimApostrophes drops elided articles ("l'amour" -> "amour"), possessives
+// ("John's" -> "John") and joins what is left ("don't" -> "dont"). Offsets
+// are narrowed to the kept text.
+func trimApostrophes(s segment) segment {
+	if !strings.ContainsAny(s.text, "'’") {
+		return s
+	}
+	word := strings.ReplaceAll(s.text, "’", "'")
+	offset := func(i int) int {
+		// Map a byte index in word back to s.text; ’ is three bytes wide.
+		n := 0
+		for j, r := range s.text {
+			if n >= i {
+				return s.start + j
+			}
+			if r == '’' {
+				n++
+			} else {
+				n += len(string(r))
+			}
+		}
+		return s.end
+	}
+
+	start, end := 0, len(word)
+	if i := strings.IndexByte(word, '\''); i > 0 && elisions[strings.ToLower(word[:i])] {
+		start = i + 1
+	}
+	if lower := strings.ToLower(word[start:]); strings.HasSuffix(lower, "'s") {
+		end -= 2
+	}
+
+	kept := strings.ReplaceAll(word[start:end], "'", "")
+	return segment{text: kept, start: offset(start), end: offset(end), kind: s.kind}
+}
+
+// NewStandardAnalyzer is the analyzer behind NewStandardTokenizer with its
+// defaults.
+func NewStandardAnalyzer() *Analyzer {
+	return NewStandardTokenizer().analyzer
 }
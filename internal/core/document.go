@@ -5,6 +5,7 @@ type StatusString string
 const (
 	TypePending   StatusString = "Pending"
 	TypeConverted StatusString = "Converted"
+	TypeFailed    StatusString = "Failed" // the embedding retries ran out
 )
 
 type Document struct {
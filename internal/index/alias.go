@@ -0,0 +1,121 @@
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const aliasesFile = "aliases.json"
+
+var (
+	ErrAliasNotFound  = errors.New("alias not found")
+	ErrAmbiguousAlias = errors.New("alias points to several indexes")
+)
+
+// AliasAction adds an index to an alias, or removes it with Remove. An
+// alias exists while it points to at least one index.
+type AliasAction struct {
+	Alias  string
+	Index  string
+	Remove bool
+}
+
+// UpdateAliases applies the actions all at once: a request sees an alias
+// either before or after them, so removing the old index and adding the new
+// one in one call swaps an alias without a gap.
+func (c *Catalog) UpdateAliases(actions []AliasAction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	aliases := make(map[string][]string, len(c.aliases))
+	for alias, targets := range c.aliases {
+		aliases[alias] = slices.Clone(targets)
+	}
+
+	for _, a := range actions {
+		if !indexName.MatchString(a.Alias) {
+			return fmt.Errorf("%w %q", ErrInvalidIndexName, a.Alias)
+		}
+		if _, ok := c.indexes[a.Alias]; ok {
+			return fmt.Errorf("%w: %q is an index", ErrInvalidIndexName, a.Alias)
+		}
+		if _, ok := c.indexes[a.Index]; !ok {
+			return fmt.Errorf("%w: %q", ErrIndexNotFound, a.Index)
+		}
+
+		targets := aliases[a.Alias]
+		at := slices.Index(targets, a.Index)
+		switch {
+		case a.Remove && at < 0:
+			return fmt.Errorf("%w: %q does not point to %q", ErrAliasNotFound, a.Alias, a.Index)
+		case a.Remove:
+			targets = slices.Delete(targets, at, at+1)
+		case at < 0:
+			targets = append(targets, a.Index)
+			slices.Sort(targets)
+		}
+		if len(targets) == 0 {
+			delete(aliases, a.Alias)
+		} else {
+			aliases[a.Alias] = targets
+		}
+	}
+
+	if err := c.saveAliases(aliases); err != nil {
+		return err
+	}
+	c.aliases = aliases
+	return nil
+}
+
+// withoutIndex returns the aliases with name removed from them.
+func (c *Catalog) withoutIndex(name string) map[string][]string {
+	aliases := make(map[string][]string, len(c.aliases))
+	for alias, targets := range c.aliases {
+		targets = slices.DeleteFunc(slices.Clone(targets), func(t string) bool { return t == name })
+		if len(targets) > 0 {
+			aliases[alias] = targets
+		}
+	}
+	return aliases
+}
+
+func (c *Catalog) saveAliases(aliases map[string][]string) error {
+	raw, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write and rename so a crash never leaves half a file.
+	path := filepath.Join(c.dir, aliasesFile)
+	if err := os.WriteFile(path+".tmp", raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (c *Catalog) loadAliases() error {
+	raw, err := os.ReadFile(filepath.Join(c.dir, aliasesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, &c.aliases); err != nil {
+		return fmt.Errorf("parse %s: %w", aliasesFile, err)
+	}
+	// Drop indexes removed by hand since.
+	for alias, targets := range c.aliases {
+		targets = slices.DeleteFunc(targets, func(t string) bool { return c.indexes[t] == nil })
+		if len(targets) == 0 {
+			delete(c.aliases, alias)
+		} else {
+			c.aliases[alias] = targets
+		}
+	}
+	return nil
+}
@@ -0,0 +1,424 @@
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+// FieldConfig picks the analyzers of one field by name. SearchAnalyzer
+// defaults to Analyzer, and Analyzer to the analyzer of Language or else
+// "standard". Languages maps a document or query language to the analyzer
+// used for it instead of the built-in one of that language, and
+// SearchLanguages the one its queries are analyzed with if different.
+// NGrams replaces DefaultNGrams for the field.
+type FieldConfig struct {
+	Analyzer        string            `json:"analyzer,omitempty"`
+	SearchAnalyzer  string            `json:"search_analyzer,omitempty"`
+	Language        string            `json:"language,omitempty"`
+	Languages       map[string]string `json:"languages,omitempty"`
+	SearchLanguages map[string]string `json:"search_languages,omitempty"`
+	NGrams          *NGramConfig      `json:"ngrams,omitempty"`
+}
+
+// Config is the analysis section of an index configuration file.
+type Config struct {
+	Analysis analysis.Config        `json:"analysis"`
+	Fields   map[string]FieldConfig `json:"fields,omitempty"`
+}
+
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Options builds the configured analyzers from registry and returns the
+// options that install them.
+func (c Config) Options(registry *analysis.Registry) ([]Option, error) {
+	analyzers, err := registry.Build(c.Analysis)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []Option{withNamedAnalyzers(analyzers)}
+	for field, fc := range c.Fields {
+		indexName, err := fc.analyzerName()
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field, err)
+		}
+		searchName := fc.SearchAnalyzer
+		if searchName == "" {
+			searchName = indexName
+		}
+
+		indexAnalyzer, ok := analyzers[indexName]
+		if !ok {
+			return nil, fmt.Errorf("field %q: unknown analyzer %q", field, indexName)
+		}
+		searchAnalyzer, ok := analyzers[searchName]
+		if !ok {
+			return nil, fmt.Errorf("field %q: unknown search analyzer %q", field, searchName)
+		}
+		opts = append(opts, WithAnalyzers(field, indexAnalyzer, searchAnalyzer))
+		if fc.Language != "" {
+			opts = append(opts, WithFieldLanguage(field, fc.Language))
+		}
+		if fc.NGrams != nil {
+			if err := fc.NGrams.validate(); err != nil {
+				return nil, fmt.Errorf("field %q: %w", field, err)
+			}
+			opts = append(opts, WithNGrams(field, *fc.NGrams))
+		}
+
+		langs := slices.Collect(maps.Keys(fc.Languages))
+		for lang := range fc.SearchLanguages {
+			if _, ok := fc.Languages[lang]; !ok {
+				langs = append(langs, lang)
+			}
+		}
+		for _, lang := range langs {
+			canonical, err := analysis.CanonicalLanguage(lang)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", field, err)
+			}
+			name := fc.Languages[lang]
+			if name == "" {
+				name = canonical
+			}
+			searchName := fc.SearchLanguages[lang]
+			if searchName == "" {
+				searchName = name
+			}
+			a, ok := analyzers[name]
+			if !ok {
+				return nil, fmt.Errorf("field %q: unknown analyzer %q for language %q", field, name, lang)
+			}
+			sa, ok := analyzers[searchName]
+			if !ok {
+				return nil, fmt.Errorf("field %q: unknown search analyzer %q for language %q", field, searchName, lang)
+			}
+			opts = append(opts, WithLanguageAnalyzers(field, canonical, a, sa))
+		}
+	}
+	return opts, nil
+}
+
+func (fc FieldConfig) analyzerName() (string, error) {
+	switch {
+	case fc.Analyzer != "":
+		return fc.Analyzer, nil
+	case fc.Language != "":
+		return analysis.CanonicalLanguage(fc.Language)
+	}
+	return "standard", nil
+}
+
+// AddSynonyms makes field's search analyzer expand the synonyms in path,
+// appended to whatever analyzer the field searches with now.
+func (c *Config) AddSynonyms(field, path, format string) {
+	c.init()
+	filter := field + "_synonyms"
+	c.Analysis.Filters[filter] = analysis.ComponentConfig{
+		Type:   "synonym_graph",
+		Params: analysis.Params{"path": path, "format": format},
+	}
+
+	fc := c.fieldConfig(field)
+	base := fc.SearchAnalyzer
+	if base == "" {
+		base = fc.Analyzer
+	}
+	fc.SearchAnalyzer = c.appendFilter(base, filter)
+
+	// Queries detected as another language are searched with that
+	// language's analyzer, which needs the synonyms as well.
+	for _, lang := range c.otherLanguages(fc) {
+		base := fc.SearchLanguages[lang]
+		if base == "" {
+			base = fc.languageAnalyzer(lang)
+		}
+		fc.SearchLanguages = withEntry(fc.SearchLanguages, lang, c.appendFilter(base, filter))
+	}
+	c.Fields[field] = fc
+}
+
+func (c *Config) appendFilter(base, filter string) string {
+	def := c.analyzerDef(base)
+	def.Filters = append(slices.Clone(def.Filters), filter)
+	name := base + "_" + filter
+	c.Analysis.Analyzers[name] = def
+	return name
+}
+
+// AddShingles indexes and queries field with shingles of two up to maxSize
+// words, joined without a separator.
+func (c *Config) AddShingles(field string, maxSize int) {
+	c.init()
+	filter := field + "_shingles"
+	c.Analysis.Filters[filter] = analysis.ComponentConfig{
+		Type:   "shingle",
+		Params: analysis.Params{"min_size": 2, "max_size": maxSize},
+	}
+	c.insertFilter(field, filter)
+}
+
+// AddDecompounder splits field's compounds into the words listed in path,
+// at index and query time.
+func (c *Config) AddDecompounder(field, path string, linking []string) {
+	c.init()
+	filter := field + "_decompound"
+	c.Analysis.Filters[filter] = analysis.ComponentConfig{
+		Type:   "decompound",
+		Params: analysis.Params{"path": path, "linking": linking},
+	}
+	c.insertFilter(field, filter)
+}
+
+func (c *Config) init() {
+	if c.Analysis.Filters == nil {
+		c.Analysis.Filters = make(map[string]analysis.ComponentConfig)
+	}
+	if c.Analysis.Analyzers == nil {
+		c.Analysis.Analyzers = make(map[string]analysis.AnalyzerConfig)
+	}
+	if c.Fields == nil {
+		c.Fields = make(map[string]FieldConfig)
+	}
+}
+
+// fieldConfig returns field's settings with Analyzer filled in.
+func (c *Config) fieldConfig(field string) FieldConfig {
+	fc := c.Fields[field]
+	if fc.Analyzer == "" {
+		name, err := fc.analyzerName()
+		if err != nil {
+			name = "standard"
+		}
+		fc.Analyzer = name
+	}
+	return fc
+}
+
+func (c *Config) analyzerDef(name string) analysis.AnalyzerConfig {
+	if def, ok := c.Analysis.Analyzers[name]; ok {
+		return def
+	}
+	return analysis.BuiltinAnalyzers[name]
+}
+
+// insertFilter adds filter to both analyzers of field, and to those of the
+// other languages its documents and queries may be detected as, ahead of
+// the stemmer so the words it produces are stemmed like the others.
+func (c *Config) insertFilter(field, filter string) {
+	fc := c.fieldConfig(field)
+	search := fc.SearchAnalyzer
+	fc.Analyzer = c.deriveAnalyzer(fc.Analyzer, filter)
+	if search != "" {
+		fc.SearchAnalyzer = c.deriveAnalyzer(search, filter)
+	}
+	for _, lang := range c.otherLanguages(fc) {
+		if search := fc.SearchLanguages[lang]; search != "" {
+			fc.SearchLanguages[lang] = c.deriveAnalyzer(search, filter)
+		}
+		fc.Languages = withEntry(fc.Languages, lang, c.deriveAnalyzer(fc.languageAnalyzer(lang), filter))
+	}
+	c.Fields[field] = fc
+}
+
+// otherLanguages are the languages a field's text may be analyzed as
+// besides its own, which uses the field's analyzers.
+func (c *Config) otherLanguages(fc FieldConfig) []string {
+	own := "english"
+	if fc.Language != "" {
+		if name, err := analysis.CanonicalLanguage(fc.Language); err == nil {
+			own = name
+		}
+	}
+	return slices.DeleteFunc(analysis.Languages(), func(lang string) bool { return lang == own })
+}
+
+// languageAnalyzer is the analyzer fc indexes text in lang with.
+func (fc FieldConfig) languageAnalyzer(lang string) string {
+	if name := fc.Languages[lang]; name != "" {
+		return name
+	}
+	return lang
+}
+
+func withEntry(m map[string]string, key, value string) map[string]string {
+	if m == nil {
+		m = make(map[string]string)
+	}
+	m[key] = value
+	return m
+}
+
+func (c *Config) deriveAnalyzer(base, filter string) string {
+	def := c.analyzerDef(base)
+	at := len(def.Filters)
+	for i, ref := range def.Filters {
+		comp, ok := c.Analysis.Filters[ref]
+		if !ok {
+			comp, ok = analysis.BuiltinFilters[ref]
+		}
+		if !ok {
+			comp.Type = ref
+		}
+		if comp.Type == "porter_stem" || comp.Type == "stemmer" {
+			at = i
+			break
+		}
+	}
+	def.Filters = slices.Insert(slices.Clone(def.Filters), at, filter)
+
+	name := base + "_" + filter
+	c.Analysis.Analyzers[name] = def
+	return name
+}
+
+type fieldAnalyzers struct {
+	index, search *analysis.Analyzer
+}
+
+// WithAnalyzers sets the analyzers a field is indexed and queried with.
+func WithAnalyzers(field string, indexAnalyzer, searchAnalyzer *analysis.Analyzer) Option {
+	return func(idx *InMemoryIndex) {
+		idx.analyzers[field] = fieldAnalyzers{index: indexAnalyzer, search: searchAnalyzer}
+	}
+}
+
+// withNamedAnalyzers adds to the analyzers of earlier configurations, so an
+// index's own configuration extends the server's.
+func withNamedAnalyzers(analyzers map[string]*analysis.Analyzer) Option {
+	return func(idx *InMemoryIndex) {
+		if idx.namedAnalyzers == nil {
+			idx.namedAnalyzers = make(map[string]*analysis.Analyzer)
+		}
+		maps.Copy(idx.namedAnalyzers, analyzers)
+	}
+}
+
+var standardAnalyzer = analysis.NewStandardAnalyzer()
+
+var builtinAnalyzers = mustBuildBuiltins()
+
+// mustBuildBuiltins builds the built-in analyzers. They have no settings, so
+// an error means a broken definition and the package must not start.
+func mustBuildBuiltins() map[string]*analysis.Analyzer {
+	analyzers, err := analysis.DefaultRegistry.Build(analysis.Config{})
+	if err != nil {
+		panic(fmt.Sprintf("index: building the built-in analyzers: %v", err))
+	}
+	return analyzers
+}
+
+func (idx *InMemoryIndex) indexAnalyzer(field string) *analysis.Analyzer {
+	return idx.analyzersFor(field, "").index
+}
+
+func (idx *InMemoryIndex) searchAnalyzer(field string) *analysis.Analyzer {
+	return idx.analyzersFor(field, "").search
+}
+
+// searchTerms runs the query through the field's search analyzer for lang.
+func (idx *InMemoryIndex) searchTerms(field, lang, query string) []string {
+	return idx.analyzersFor(field, lang).search.Tokenize(query)
+}
+
+var ErrUnknownAnalyzer = errors.New("unknown analyzer")
+
+// AnalyzeOptions picks the analyzer for Analyze: a named one, or the index
+// analyzer of Field (the search analyzer if Search is set). Field defaults to
+// DefaultField and also decides the phonetic encoders reported.
+type AnalyzeOptions struct {
+	Analyzer string
+	Field    string
+	Search   bool
+}
+
+type PhoneticCode struct {
+	Encoder string
+	Code    string
+}
+
+// AnalyzedToken is one token as Add sees it, with the keys it is indexed
+// under.
+type AnalyzedToken struct {
+	analysis.Token
+	Text        string // the original text the token covers
+	EdgeNgrams  []string
+	InfixNgrams []string
+	Phonetic    []PhoneticCode
+}
+
+// Analyze runs text through an analyzer without indexing it, for debugging
+// why a query does or does not match. It returns the name of the analyzer
+// used.
+func (idx *InMemoryIndex) Analyze(text string, opts AnalyzeOptions) (string, []AnalyzedToken, error) {
+	field := opts.Field
+	if field == "" {
+		field = DefaultField
+	}
+
+	var analyzer *analysis.Analyzer
+	switch {
+	case opts.Analyzer != "":
+		a, err := idx.analyzerByName(opts.Analyzer)
+		if err != nil {
+			return "", nil, err
+		}
+		analyzer = a
+	case opts.Search:
+		analyzer = idx.searchAnalyzer(field)
+	default:
+		analyzer = idx.indexAnalyzer(field)
+	}
+
+	ngrams := idx.fieldNGrams(field)
+	var out []AnalyzedToken
+	for _, t := range analyzer.Analyze(text) {
+		at := AnalyzedToken{
+			Token:       t,
+			Text:        surfaceText(text, t),
+			EdgeNgrams:  ngrams.edge(t.Term),
+			InfixNgrams: ngrams.infix(t.Term),
+		}
+		for _, key := range idx.phoneticKeys(field, t.Term) {
+			encoder, code := splitPhoneticKey(key)
+			at.Phonetic = append(at.Phonetic, PhoneticCode{Encoder: encoder, Code: code})
+		}
+		out = append(out, at)
+	}
+	return analyzer.Name, out, nil
+}
+
+// analyzerByName looks name up among the configured analyzers, then the
+// built-in ones.
+func (idx *InMemoryIndex) analyzerByName(name string) (*analysis.Analyzer, error) {
+	if a, ok := idx.namedAnalyzers[name]; ok {
+		return a, nil
+	}
+	if a, ok := builtinAnalyzers[name]; ok {
+		return a, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownAnalyzer, name)
+}
+
+func surfaceText(text string, t analysis.Token) string {
+	if t.Start < 0 || t.End > len(text) || t.Start > t.End {
+		return ""
+	}
+	return text[t.Start:t.End]
+}
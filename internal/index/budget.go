@@ -0,0 +1,36 @@
+package index
+
+import (
+	"context"
+	"time"
+)
+
+// WithSemanticBudget caps the time one search may spend on embedding calls.
+// Zero means the semantic leg is only bounded by the caller's deadline.
+func WithSemanticBudget(d time.Duration) Option {
+	return func(idx *InMemoryIndex) {
+		idx.semanticBudget = d
+	}
+}
+
+// semanticContext derives the deadline for the semantic leg: the configured
+// budget, shortened so that a fifth of the caller's remaining time is left
+// for lexical ranking and the reply.
+func (idx *InMemoryIndex) semanticContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	budget := idx.semanticBudget
+
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline) * 4 / 5
+		if remaining <= 0 {
+			remaining = time.Nanosecond
+		}
+		if budget <= 0 || remaining < budget {
+			budget = remaining
+		}
+	}
+
+	if budget <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, budget)
+}
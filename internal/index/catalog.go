@@ -0,0 +1,317 @@
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+// DefaultIndex serves requests that name no index. It is created on first
+// use so clients written before named indexes keep working.
+const DefaultIndex = "default"
+
+const (
+	settingsFile = "settings.json"
+	snapshotFile = "index.db"
+)
+
+var (
+	ErrIndexNotFound    = errors.New("index not found")
+	ErrInvalidIndexName = errors.New("invalid index name")
+	ErrInvalidConfig    = errors.New("invalid index configuration")
+)
+
+// Index names double as directory names.
+var indexName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// IndexSettings is what an index is created with and reopened from: its
+// schema and its own analysis configuration, applied over the catalog's
+// options.
+type IndexSettings struct {
+	Schema *Schema `json:"schema,omitempty"`
+	Config Config  `json:"config"`
+}
+
+// IndexInfo describes one index of a catalog.
+type IndexInfo struct {
+	Name          string
+	Documents     int
+	Pending       int
+	SchemaVersion int64 // 0 if the index has no schema
+	Aliases       []string
+}
+
+// Catalog holds the named indexes of a server, each persisted in its own
+// directory under dir.
+type Catalog struct {
+	mu      sync.RWMutex
+	dir     string
+	opts    []Option
+	indexes map[string]*InMemoryIndex
+	aliases map[string][]string // alias -> index names, sorted
+
+	jobsMu  sync.Mutex
+	jobs    map[string]*ReindexJob
+	nextJob int
+}
+
+// OpenCatalog loads every index found under dir. opts are given to every
+// index before its own settings.
+func OpenCatalog(dir string, opts ...Option) (*Catalog, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &Catalog{
+		dir:     dir,
+		opts:    opts,
+		indexes: make(map[string]*InMemoryIndex),
+		aliases: make(map[string][]string),
+		jobs:    make(map[string]*ReindexJob),
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() || !indexName.MatchString(e.Name()) {
+			continue
+		}
+		settings, err := readSettings(filepath.Join(dir, e.Name(), settingsFile))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("index %q: %w", e.Name(), err)
+		}
+		idx, err := c.newIndex(settings)
+		if err != nil {
+			return nil, fmt.Errorf("index %q: %w", e.Name(), err)
+		}
+		if err := idx.Load(c.snapshotPath(e.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("index %q: %w", e.Name(), err)
+		}
+		c.indexes[e.Name()] = idx
+	}
+
+	if err := c.loadAliases(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func readSettings(path string) (IndexSettings, error) {
+	var s IndexSettings
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return s, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (c *Catalog) snapshotPath(name string) string {
+	return filepath.Join(c.dir, name, snapshotFile)
+}
+
+func (c *Catalog) newIndex(settings IndexSettings) (*InMemoryIndex, error) {
+	analyzerOpts, err := settings.Config.Options(analysis.DefaultRegistry)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	opts := append(append([]Option{}, c.opts...), analyzerOpts...)
+	idx := NewInMemoryIndex(opts...)
+
+	if settings.Schema != nil {
+		if err := idx.CreateSchema(*settings.Schema); err != nil {
+			idx.Close()
+			return nil, err
+		}
+	}
+	return idx, nil
+}
+
+// Create adds an empty index and writes its settings.
+func (c *Catalog) Create(name string, settings IndexSettings) (*InMemoryIndex, error) {
+	if !indexName.MatchString(name) {
+		return nil, fmt.Errorf("%w %q: use up to 64 lower-case letters, digits, - and _", ErrInvalidIndexName, name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.indexes[name]; ok {
+		return nil, fmt.Errorf("%w: %q", ErrIndexExists, name)
+	}
+	if _, ok := c.aliases[name]; ok {
+		return nil, fmt.Errorf("%w: %q is an alias", ErrIndexExists, name)
+	}
+
+	idx, err := c.newIndex(settings)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.MarshalIndent(settings, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Join(c.dir, name), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(filepath.Join(c.dir, name, settingsFile), raw, 0o644)
+	}
+	if err != nil {
+		idx.Close()
+		return nil, err
+	}
+
+	c.indexes[name] = idx
+	return idx, nil
+}
+
+// Get returns the named index, or the one index an alias points to; the
+// default index is created if missing.
+func (c *Catalog) Get(name string) (*InMemoryIndex, error) {
+	indexes, err := c.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) > 1 {
+		return nil, fmt.Errorf("%w: %q points to %d indexes", ErrAmbiguousAlias, name, len(indexes))
+	}
+	return indexes[0], nil
+}
+
+// Resolve returns the named index, or every index an alias points to.
+func (c *Catalog) Resolve(name string) ([]*InMemoryIndex, error) {
+	if name == "" {
+		name = DefaultIndex
+	}
+	c.mu.RLock()
+	idx, ok := c.indexes[name]
+	var indexes []*InMemoryIndex
+	for _, target := range c.aliases[name] {
+		indexes = append(indexes, c.indexes[target])
+	}
+	c.mu.RUnlock()
+	if ok {
+		return []*InMemoryIndex{idx}, nil
+	}
+	if len(indexes) > 0 {
+		return indexes, nil
+	}
+	if name != DefaultIndex {
+		return nil, fmt.Errorf("%w: %q", ErrIndexNotFound, name)
+	}
+
+	idx, err := c.Create(DefaultIndex, IndexSettings{})
+	if errors.Is(err, ErrIndexExists) {
+		return c.Resolve(DefaultIndex)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []*InMemoryIndex{idx}, nil
+}
+
+// Exists reports whether the named index exists, without creating the
+// default one.
+func (c *Catalog) Exists(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.indexes[name]
+	return ok
+}
+
+// Delete stops the index and the reindex jobs using it, and removes it with
+// its directory and from every alias.
+func (c *Catalog) Delete(name string) error {
+	c.mu.Lock()
+	idx, ok := c.indexes[name]
+	if !ok {
+		c.mu.Unlock()
+		return fmt.Errorf("%w: %q", ErrIndexNotFound, name)
+	}
+	delete(c.indexes, name)
+	aliases := c.withoutIndex(name)
+	err := c.saveAliases(aliases)
+	if err == nil {
+		c.aliases = aliases
+	}
+	c.mu.Unlock()
+	if err != nil {
+		log.Printf("⚠️ Failed to save aliases: %v", err)
+	}
+
+	c.cancelJobs(idx)
+	idx.Close()
+	return os.RemoveAll(filepath.Join(c.dir, name))
+}
+
+// List describes the indexes by name.
+func (c *Catalog) List() []IndexInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	infos := make([]IndexInfo, 0, len(c.indexes))
+	for name, idx := range c.indexes {
+		stats := idx.PipelineStats()
+		info := IndexInfo{
+			Name:      name,
+			Documents: stats.Pending + stats.Converted + stats.Failed,
+			Pending:   stats.Pending,
+		}
+		if s, ok := idx.Schema(); ok {
+			info.SchemaVersion = s.Version
+		}
+		for alias, targets := range c.aliases {
+			if slices.Contains(targets, name) {
+				info.Aliases = append(info.Aliases, alias)
+			}
+		}
+		sort.Strings(info.Aliases)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// Close stops the reindex jobs, then every index, and saves each to its
+// directory.
+func (c *Catalog) Close() error {
+	c.stopJobs()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+	for name, idx := range c.indexes {
+		idx.Close()
+		if err := idx.Save(c.snapshotPath(name)); err != nil {
+			errs = append(errs, fmt.Errorf("index %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Import loads a snapshot written outside the catalog, such as the single
+// index file of older servers, into the named index.
+func (c *Catalog) Import(name, path string) error {
+	idx, err := c.Get(name)
+	if err != nil {
+		return err
+	}
+	if err := idx.Load(path); err != nil {
+		return err
+	}
+	log.Printf("Imported %s into index %q", path, name)
+	return nil
+}
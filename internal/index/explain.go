@@ -0,0 +1,203 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrDocumentNotFound = errors.New("document not found")
+
+// Explanation is one node of a score breakdown: Value is what this node
+// contributed and Details are the parts it was computed from.
+type Explanation struct {
+	Description string
+	Value       float64
+	Details     []*Explanation
+}
+
+const (
+	matchFragment = "fragment"
+	matchPhonetic = "phonetic"
+	matchFuzzy    = "fuzzy"
+	matchPattern  = "pattern"
+	matchField    = "field"
+	matchInfix    = "infix"
+	matchNeighbor = "neighbor"
+	matchBonus    = "bonus"
+)
+
+type matchEvent struct {
+	token string // query token the event is credited to, empty for bonuses
+	kind  string
+	key   string // what matched: the fragment, code, candidate or neighbor
+	note  string
+	score float64
+}
+
+type legTrace struct {
+	keywordRank, vectorRank int // 1-based, 0 when the leg did not rank the document
+	keywordRaw, vectorRaw   float64
+	keywordNorm, vectorNorm float64
+	keywordPart, vectorPart float64
+}
+
+// searchTrace records why documents scored what they did. A nil trace
+// records nothing, so the hot path only pays a nil check when not explaining.
+type searchTrace struct {
+	tokens []string
+	events map[uint32][]matchEvent
+	legs   map[uint32]*legTrace
+}
+
+func newSearchTrace(enabled bool, tokens []string) *searchTrace {
+	if !enabled {
+		return nil
+	}
+	return &searchTrace{
+		tokens: tokens,
+		events: make(map[uint32][]matchEvent),
+		legs:   make(map[uint32]*legTrace),
+	}
+}
+
+func (t *searchTrace) match(id uint32, token, kind, key, note string, score float64) {
+	if t == nil {
+		return
+	}
+	t.events[id] = append(t.events[id], matchEvent{token: token, kind: kind, key: key, note: note, score: score})
+}
+
+func (t *searchTrace) resetLegs() {
+	if t == nil {
+		return
+	}
+	t.legs = make(map[uint32]*legTrace)
+}
+
+func (t *searchTrace) leg(id uint32) *legTrace {
+	if t == nil {
+		return nil
+	}
+	l, ok := t.legs[id]
+	if !ok {
+		l = &legTrace{}
+		t.legs[id] = l
+	}
+	return l
+}
+
+func (t *searchTrace) explain(id uint32, final float64, fusion FusionConfig, metric VectorMetric) *Explanation {
+	leg := t.leg(id)
+
+	root := &Explanation{Value: final}
+	if fusion.Method == FusionRRF {
+		root.Description = fmt.Sprintf("rrf fusion (k=%g) of keyword and vector legs", fusion.K)
+	} else {
+		root.Description = fmt.Sprintf("%s fusion of keyword and vector legs", fusion.Method)
+	}
+
+	keyword := &Explanation{Value: leg.keywordPart}
+	vector := &Explanation{Value: leg.vectorPart}
+
+	switch {
+	case fusion.Method == FusionRRF:
+		keyword.Description = rrfDescription("keyword", leg.keywordRank, *fusion.KeywordWeight, fusion.K)
+		vector.Description = rrfDescription("vector", leg.vectorRank, *fusion.VectorWeight, fusion.K)
+	default:
+		norm := string(fusion.Normalization)
+		if fusion.Method == FusionDBSF {
+			norm = "3-sigma"
+		}
+		keyword.Description = fmt.Sprintf("keyword leg: weight %g × %s-normalized %.4f", *fusion.KeywordWeight, norm, leg.keywordNorm)
+		vector.Description = fmt.Sprintf("vector leg: weight %g × %s-normalized %.4f", *fusion.VectorWeight, norm, leg.vectorNorm)
+	}
+
+	keyword.Details = []*Explanation{t.keywordBreakdown(id, leg.keywordRaw)}
+	vector.Details = []*Explanation{{Description: metric.describe(), Value: leg.vectorRaw}}
+	root.Details = []*Explanation{keyword, vector}
+
+	return root
+}
+
+func rrfDescription(name string, rank int, weight, k float64) string {
+	if rank == 0 {
+		return fmt.Sprintf("%s leg: not ranked", name)
+	}
+	return fmt.Sprintf("%s leg: weight %g / (k %g + rank %d)", name, weight, k, rank)
+}
+
+func (t *searchTrace) keywordBreakdown(id uint32, raw float64) *Explanation {
+	node := &Explanation{Description: "keyword score", Value: raw}
+
+	byToken := make(map[string]*Explanation)
+	for _, token := range t.tokens {
+		if _, ok := byToken[token]; ok {
+			continue
+		}
+		byToken[token] = &Explanation{Description: fmt.Sprintf("query token %q", token)}
+		node.Details = append(node.Details, byToken[token])
+	}
+
+	for _, ev := range t.events[id] {
+		detail := &Explanation{Description: describeEvent(ev), Value: ev.score}
+		parent, ok := byToken[ev.token]
+		if !ok {
+			node.Details = append(node.Details, detail)
+			continue
+		}
+		parent.Value += ev.score
+		parent.Details = append(parent.Details, detail)
+	}
+
+	// Tokens that matched nothing stay in the tree with value 0, that is
+	// often the interesting part.
+	return node
+}
+
+func describeEvent(ev matchEvent) string {
+	switch ev.kind {
+	case matchFragment:
+		return fmt.Sprintf("edge n-gram %q matched", ev.key)
+	case matchPhonetic:
+		encoder, code := splitPhoneticKey(ev.key)
+		return fmt.Sprintf("%s code %s matched", encoder, code)
+	case matchFuzzy:
+		return fmt.Sprintf("fuzzy candidate %q matched (%s)", ev.key, ev.note)
+	case matchInfix:
+		return fmt.Sprintf("infix n-grams of %q matched (%s)", ev.key, ev.note)
+	case matchPattern:
+		return fmt.Sprintf("expanded term %q matched", ev.key)
+	case matchNeighbor:
+		return fmt.Sprintf("semantic neighbor %q matched (%s)", ev.key, ev.note)
+	case matchField:
+		return fmt.Sprintf("matched in field %q", ev.key)
+	}
+	return ev.note
+}
+
+// Explain runs the query without truncating the result list and returns the
+// score breakdown for one document along with its 1-based rank, or rank 0 if
+// the document did not match at all.
+func (idx *InMemoryIndex) Explain(ctx context.Context, query string, docID string, opts SearchOptions) (*Explanation, int, error) {
+	internalID := internalIDFor(docID)
+
+	idx.mu.RLock()
+	_, exists := idx.idMapping[internalID]
+	idx.mu.RUnlock()
+	if !exists {
+		return nil, 0, ErrDocumentNotFound
+	}
+
+	opts.Explain = true
+	opts.unlimited = true
+	results := idx.Search(ctx, query, opts)
+
+	for rank, hit := range results.Hits {
+		if hit.ID == docID {
+			return hit.Explanation, rank + 1, nil
+		}
+	}
+
+	return &Explanation{Description: "document did not match the query"}, 0, nil
+}
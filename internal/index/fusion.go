@@ -0,0 +1,232 @@
+package index
+
+import (
+	"fmt"
+	"math"
+)
+
+type FusionMethod string
+
+const (
+	// FusionRRF merges the keyword and vector rankings by reciprocal rank.
+	FusionRRF FusionMethod = "rrf"
+	// FusionConvex normalizes both legs' raw scores and takes a weighted sum.
+	FusionConvex FusionMethod = "convex"
+	// FusionDBSF is distribution-based score fusion: each leg is scaled to
+	// [0, 1] using mean ± 3 standard deviations before the weighted sum.
+	FusionDBSF FusionMethod = "dbsf"
+)
+
+type Normalization string
+
+const (
+	NormalizeMinMax Normalization = "minmax"
+	NormalizeZScore Normalization = "zscore"
+)
+
+// FusionConfig decides how the keyword and vector legs are combined.
+// Unset fields take the method's defaults, see withDefaults. A weight is
+// unset when nil, so 0 turns its leg off.
+type FusionConfig struct {
+	Method        FusionMethod
+	K             float64 // RRF rank constant
+	KeywordWeight *float64
+	VectorWeight  *float64
+	Normalization Normalization // convex only
+}
+
+// Weight returns a pointer to w, for setting FusionConfig weights.
+func Weight(w float64) *float64 {
+	return &w
+}
+
+// DefaultFusion is the historic ranking: RRF with k = 60 and the keyword leg
+// weighted 100 times the vector leg.
+var DefaultFusion = FusionConfig{Method: FusionRRF, K: 60, KeywordWeight: Weight(100), VectorWeight: Weight(1)}
+
+// withDefaults fills every unset field. Both weights are set afterwards.
+func (f FusionConfig) withDefaults() FusionConfig {
+	if f.Method == "" {
+		f.Method = FusionRRF
+	}
+
+	keyword, vector := Weight(0.5), Weight(0.5)
+	if f.Method == FusionRRF {
+		keyword, vector = Weight(*DefaultFusion.KeywordWeight), Weight(*DefaultFusion.VectorWeight)
+	}
+	if f.KeywordWeight == nil {
+		f.KeywordWeight = keyword
+	}
+	if f.VectorWeight == nil {
+		f.VectorWeight = vector
+	}
+	if f.K <= 0 {
+		f.K = DefaultFusion.K
+	}
+	if f.Normalization == "" {
+		f.Normalization = NormalizeMinMax
+	}
+
+	return f
+}
+
+func (f FusionConfig) Validate() error {
+	switch f.Method {
+	case "", FusionRRF, FusionConvex, FusionDBSF:
+	default:
+		return fmt.Errorf("unknown fusion method %q", f.Method)
+	}
+	switch f.Normalization {
+	case "", NormalizeMinMax, NormalizeZScore:
+	default:
+		return fmt.Errorf("unknown normalization %q", f.Normalization)
+	}
+	if f.K < 0 || negative(f.KeywordWeight) || negative(f.VectorWeight) {
+		return fmt.Errorf("fusion k and weights must not be negative")
+	}
+	return nil
+}
+
+func negative(w *float64) bool {
+	return w != nil && *w < 0
+}
+
+// WithFusion sets the fusion used when a search request does not pick one.
+func WithFusion(f FusionConfig) Option {
+	return func(idx *InMemoryIndex) {
+		idx.fusion = f
+	}
+}
+
+// fusionFor layers the request's fusion settings over the index default;
+// each one the request sets replaces the default's, so setting one weight
+// keeps the other. Switching methods drops the default's parameters, they
+// rarely carry over.
+func (idx *InMemoryIndex) fusionFor(opts SearchOptions) FusionConfig {
+	f := idx.fusion
+	req := opts.Fusion
+	if req == nil {
+		return f.withDefaults()
+	}
+
+	if req.Method != "" && req.Method != f.withDefaults().Method {
+		f = FusionConfig{Method: req.Method}
+	}
+	f = f.withDefaults()
+	if req.K > 0 {
+		f.K = req.K
+	}
+	if req.KeywordWeight != nil {
+		f.KeywordWeight = req.KeywordWeight
+	}
+	if req.VectorWeight != nil {
+		f.VectorWeight = req.VectorWeight
+	}
+	if req.Normalization != "" {
+		f.Normalization = req.Normalization
+	}
+	return f
+}
+
+// fuseScores implements the score-based methods. The keyword leg only covers
+// documents with a lexical hit, the vector leg every embedded document; a
+// document missing from a leg contributes nothing for it.
+func (idx *InMemoryIndex) fuseScores(keywordScores, vectorScores map[uint32]float64, fusion FusionConfig, trace *searchTrace) []SearchResponse {
+	keyword := make(map[uint32]float64)
+	for id, score := range keywordScores {
+		if score > 0 {
+			keyword[id] = score
+		}
+	}
+
+	var normalize func(map[uint32]float64) map[uint32]float64
+	switch {
+	case fusion.Method == FusionDBSF:
+		normalize = normalizeDistribution
+	case fusion.Normalization == NormalizeZScore:
+		normalize = normalizeZScore
+	default:
+		normalize = normalizeMinMax
+	}
+
+	fused := make(map[uint32]float64)
+	for id, score := range normalize(keyword) {
+		fused[id] += *fusion.KeywordWeight * score
+		if leg := trace.leg(id); leg != nil {
+			leg.keywordRaw, leg.keywordNorm, leg.keywordPart = keyword[id], score, *fusion.KeywordWeight*score
+		}
+	}
+	for id, score := range normalize(vectorScores) {
+		fused[id] += *fusion.VectorWeight * score
+		if leg := trace.leg(id); leg != nil {
+			leg.vectorRaw, leg.vectorNorm, leg.vectorPart = vectorScores[id], score, *fusion.VectorWeight*score
+		}
+	}
+
+	return idx.sortedResponses(fused)
+}
+
+func normalizeMinMax(scores map[uint32]float64) map[uint32]float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range scores {
+		lo, hi = min(lo, s), max(hi, s)
+	}
+
+	out := make(map[uint32]float64, len(scores))
+	for id, s := range scores {
+		if hi == lo {
+			out[id] = 1
+			continue
+		}
+		out[id] = (s - lo) / (hi - lo)
+	}
+	return out
+}
+
+func normalizeZScore(scores map[uint32]float64) map[uint32]float64 {
+	mean, std := meanStd(scores)
+
+	out := make(map[uint32]float64, len(scores))
+	for id, s := range scores {
+		if std == 0 {
+			out[id] = 0
+			continue
+		}
+		out[id] = (s - mean) / std
+	}
+	return out
+}
+
+func normalizeDistribution(scores map[uint32]float64) map[uint32]float64 {
+	mean, std := meanStd(scores)
+	lo, hi := mean-3*std, mean+3*std
+
+	out := make(map[uint32]float64, len(scores))
+	for id, s := range scores {
+		if hi == lo {
+			out[id] = 1
+			continue
+		}
+		out[id] = math.Min(1, math.Max(0, (s-lo)/(hi-lo)))
+	}
+	return out
+}
+
+func meanStd(scores map[uint32]float64) (float64, float64) {
+	if len(scores) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	mean := sum / float64(len(scores))
+
+	var variance float64
+	for _, s := range scores {
+		variance += (s - mean) * (s - mean)
+	}
+
+	return mean, math.Sqrt(variance / float64(len(scores)))
+}
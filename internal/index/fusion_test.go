@@ -0,0 +1,108 @@
+package index
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFusionFor(t *testing.T) {
+	tests := []struct {
+		name            string
+		index           FusionConfig
+		request         *FusionConfig
+		method          FusionMethod
+		keyword, vector float64
+	}{
+		{"defaults", FusionConfig{}, nil, FusionRRF, 100, 1},
+		{"index vector weight only", FusionConfig{VectorWeight: Weight(5)}, nil, FusionRRF, 100, 5},
+		{"request keyword weight only", FusionConfig{}, &FusionConfig{KeywordWeight: Weight(3)}, FusionRRF, 3, 1},
+		{"request turns the keyword leg off", FusionConfig{}, &FusionConfig{KeywordWeight: Weight(0)}, FusionRRF, 0, 1},
+		{"request switches method", FusionConfig{KeywordWeight: Weight(7)}, &FusionConfig{Method: FusionConvex}, FusionConvex, 0.5, 0.5},
+		{"request switches method with a weight", FusionConfig{}, &FusionConfig{Method: FusionDBSF, VectorWeight: Weight(0.9)}, FusionDBSF, 0.5, 0.9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := NewInMemoryIndex(WithFusion(tt.index))
+			defer idx.Close()
+
+			f := idx.fusionFor(SearchOptions{Fusion: tt.request})
+			if f.Method != tt.method || *f.KeywordWeight != tt.keyword || *f.VectorWeight != tt.vector {
+				t.Errorf("got %s %g/%g, want %s %g/%g", f.Method, *f.KeywordWeight, *f.VectorWeight, tt.method, tt.keyword, tt.vector)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	scores := map[uint32]float64{1: 2, 2: 4, 3: 6}
+	tests := []struct {
+		name      string
+		normalize func(map[uint32]float64) map[uint32]float64
+		want      map[uint32]float64
+	}{
+		{"minmax", normalizeMinMax, map[uint32]float64{1: 0, 2: 0.5, 3: 1}},
+		{"zscore", normalizeZScore, map[uint32]float64{1: -math.Sqrt(1.5), 2: 0, 3: math.Sqrt(1.5)}},
+		{"distribution", normalizeDistribution, map[uint32]float64{1: 0.5 - 1/(3*math.Sqrt(8.0/3)), 2: 0.5, 3: 0.5 + 1/(3*math.Sqrt(8.0/3))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.normalize(scores)
+			for id, want := range tt.want {
+				if math.Abs(got[id]-want) > 1e-9 {
+					t.Errorf("doc %d: got %g, want %g", id, got[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestFinalizeRanks(t *testing.T) {
+	keyword := map[uint32]float64{1: 300, 2: 200}
+	vector := map[uint32]float64{1: 0.1, 2: 0.9, 3: 0.5}
+
+	tests := []struct {
+		name   string
+		fusion FusionConfig
+		want   map[string]float64
+	}{
+		{
+			name:   "rrf",
+			fusion: FusionConfig{Method: FusionRRF, K: 60, KeywordWeight: Weight(100), VectorWeight: Weight(1)},
+			want: map[string]float64{
+				"a": 100.0/61 + 1.0/63,
+				"b": 100.0/62 + 1.0/61,
+				"c": 1.0 / 62,
+			},
+		},
+		{
+			name:   "rrf vector only",
+			fusion: FusionConfig{Method: FusionRRF, K: 60, KeywordWeight: Weight(0), VectorWeight: Weight(1)},
+			want:   map[string]float64{"a": 1.0 / 63, "b": 1.0 / 61, "c": 1.0 / 62},
+		},
+		{
+			name:   "convex minmax",
+			fusion: FusionConfig{Method: FusionConvex, KeywordWeight: Weight(0.5), VectorWeight: Weight(0.5), Normalization: NormalizeMinMax},
+			want:   map[string]float64{"a": 0.5, "b": 0.5, "c": 0.25},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := NewInMemoryIndex()
+			defer idx.Close()
+			idx.idMapping[1], idx.idMapping[2], idx.idMapping[3] = "a", "b", "c"
+
+			got := idx.finalizeRanks(keyword, vector, tt.fusion, nil)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d hits, want %d", len(got), len(tt.want))
+			}
+			for i, hit := range got {
+				if math.Abs(hit.Score-tt.want[hit.ID]) > 1e-9 {
+					t.Errorf("%s: got %g, want %g", hit.ID, hit.Score, tt.want[hit.ID])
+				}
+				if i > 0 && hit.Score > got[i-1].Score {
+					t.Errorf("hits out of order at %d", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package index
+
+import (
+	"math"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+// WithFuzziness sets the edit budget used when a search request does not set
+// one. The default is AUTO.
+func WithFuzziness(f analysis.Fuzziness) Option {
+	return func(idx *InMemoryIndex) {
+		idx.fuzziness = f
+	}
+}
+
+// WithEditCosts prices the edits of fuzzy matching and spelling correction.
+// The default is analysis.TypoCosts.
+func WithEditCosts(c analysis.EditCosts) Option {
+	return func(idx *InMemoryIndex) {
+		idx.editCosts = c
+	}
+}
+
+// maxLengthSpread bounds the vocabulary buckets one fuzzy lookup visits when
+// inserts or deletes are free.
+const maxLengthSpread = 64
+
+// lengthSpread is how far, in runes, a candidate's length can be from the
+// term's within budget: each rune of difference costs an insert or delete.
+func lengthSpread(budget float64, costs analysis.EditCosts) int {
+	cheapest := min(costs.Insert, costs.Delete)
+	if cheapest <= 0 || budget/cheapest > maxLengthSpread {
+		return maxLengthSpread
+	}
+	return int(budget / cheapest)
+}
+
+// fuzzyScore rewards closer candidates; 1 edit keeps the historical 60
+// points and cheap typos can earn up to twice that.
+func fuzzyScore(dist float64) float64 {
+	return 60.0 / math.Max(dist, 0.5)
+}
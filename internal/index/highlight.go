@@ -0,0 +1,258 @@
+package index
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+type HighlightConfig struct {
+	PreTag            string // defaults to <em>
+	PostTag           string // defaults to </em>
+	FragmentSize      int    // approximate fragment length in bytes, defaults to 100
+	NumberOfFragments int    // fragments returned per hit, best first, defaults to 3
+}
+
+func (c HighlightConfig) withDefaults() HighlightConfig {
+	if c.PreTag == "" && c.PostTag == "" {
+		c.PreTag, c.PostTag = "<em>", "</em>"
+	}
+	if c.FragmentSize <= 0 {
+		c.FragmentSize = 100
+	}
+	if c.NumberOfFragments <= 0 {
+		c.NumberOfFragments = 3
+	}
+	return c
+}
+
+// docMatcher decides which stored tokens of one document the search matched,
+// replaying the same keys the scoring passes looked up.
+type docMatcher struct {
+	fragments map[string]bool // edge n-grams looked up in data
+	phonetic  map[string]bool // namespaced phonetic keys
+	encode    func(term string) []string
+	terms     map[string]bool // fuzzy candidates, matched as whole terms
+	neighbors map[string]bool // stemmed semantic neighbors and their prefixes
+	infixes   map[string]bool // query tokens found inside terms
+	ngrams    NGramConfig
+}
+
+func (t *searchTrace) matcher(id uint32, encode func(term string) []string, ngrams NGramConfig) *docMatcher {
+	m := &docMatcher{
+		encode:    encode,
+		ngrams:    ngrams,
+		infixes:   make(map[string]bool),
+		fragments: make(map[string]bool),
+		phonetic:  make(map[string]bool),
+		terms:     make(map[string]bool),
+		neighbors: make(map[string]bool),
+	}
+
+	for _, ev := range t.events[id] {
+		switch ev.kind {
+		case matchFragment:
+			m.fragments[ev.key] = true
+		case matchPhonetic:
+			m.phonetic[ev.key] = true
+		case matchFuzzy, matchPattern:
+			m.terms[ev.key] = true
+		case matchInfix:
+			m.infixes[ev.key] = true
+		case matchNeighbor:
+			m.neighbors[ev.key] = true
+			if prefix, ok := ngrams.prefix(ev.key); ok {
+				m.neighbors[prefix] = true
+			}
+		}
+	}
+
+	return m
+}
+
+func (m *docMatcher) matches(term string) bool {
+	if m.terms[term] {
+		return true
+	}
+	for infix := range m.infixes {
+		if strings.Contains(term, infix) {
+			return true
+		}
+	}
+	for _, gram := range m.ngrams.edge(term) {
+		if m.fragments[gram] || m.neighbors[gram] {
+			return true
+		}
+	}
+	if len(m.phonetic) == 0 {
+		return false
+	}
+	for _, key := range m.encode(term) {
+		if m.phonetic[key] {
+			return true
+		}
+	}
+	return false
+}
+
+type passage struct {
+	start, end int // byte offsets into the text
+	tokens     []analysis.Token
+	distinct   int
+	hits       int
+}
+
+// highlight cuts the text into passages of about FragmentSize bytes around
+// matched tokens, ranks them by how many distinct terms they match (then by
+// total matches, then by position) and wraps every matched token in tags.
+// When nothing matched, the leading passage is returned untagged.
+func highlight(text string, tokens []analysis.Token, m *docMatcher, cfg HighlightConfig) []string {
+	var matched []analysis.Token
+	for _, t := range tokens {
+		if m.matches(t.Term) {
+			matched = append(matched, t)
+		}
+	}
+
+	if len(matched) == 0 {
+		end := snapEnd(text, min(len(text), cfg.FragmentSize))
+		if end == 0 {
+			return nil
+		}
+		return []string{strings.TrimSpace(text[:end])}
+	}
+
+	var candidates []passage
+	for _, anchor := range matched {
+		start := snapStart(text, anchor.Start-cfg.FragmentSize/4)
+		end := snapEnd(text, max(start+cfg.FragmentSize, anchor.End))
+
+		p := passage{start: start, end: end}
+		seen := make(map[string]bool)
+		for _, t := range matched {
+			if t.Start >= start && t.End <= end {
+				p.tokens = append(p.tokens, t)
+				p.hits++
+				if !seen[t.Term] {
+					seen[t.Term] = true
+					p.distinct++
+				}
+			}
+		}
+		candidates = append(candidates, p)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distinct != candidates[j].distinct {
+			return candidates[i].distinct > candidates[j].distinct
+		}
+		if candidates[i].hits != candidates[j].hits {
+			return candidates[i].hits > candidates[j].hits
+		}
+		return candidates[i].start < candidates[j].start
+	})
+
+	var chosen []passage
+	for _, c := range candidates {
+		if len(chosen) == cfg.NumberOfFragments {
+			break
+		}
+		overlaps := false
+		for _, p := range chosen {
+			if c.start < p.end && p.start < c.end {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			chosen = append(chosen, c)
+		}
+	}
+
+	fragments := make([]string, 0, len(chosen))
+	for _, p := range chosen {
+		fragments = append(fragments, render(text, p, cfg))
+	}
+	return fragments
+}
+
+// snapStart moves offset back to the start of the word it falls into, so
+// passages never begin mid-word.
+func snapStart(text string, offset int) int {
+	if offset <= 0 {
+		return 0
+	}
+	for offset < len(text) && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+	for offset > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:offset])
+		if !isWordRune(r) {
+			break
+		}
+		offset -= size
+	}
+	return offset
+}
+
+// snapEnd extends offset to the end of the word it cuts through.
+func snapEnd(text string, offset int) int {
+	if offset >= len(text) {
+		return len(text)
+	}
+	for offset < len(text) && !utf8.RuneStart(text[offset]) {
+		offset++
+	}
+	for offset < len(text) {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if !isWordRune(r) {
+			break
+		}
+		offset += size
+	}
+	return offset
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+func render(text string, p passage, cfg HighlightConfig) string {
+	var b strings.Builder
+	cursor := p.start
+
+	for _, t := range p.tokens {
+		if t.Start < cursor {
+			// A compound and its parts cover the same text; mark it once.
+			continue
+		}
+		b.WriteString(text[cursor:t.Start])
+		b.WriteString(cfg.PreTag)
+		b.WriteString(text[t.Start:t.End])
+		b.WriteString(cfg.PostTag)
+		cursor = t.End
+	}
+	b.WriteString(text[cursor:p.end])
+
+	return strings.TrimSpace(b.String())
+}
+
+// highlightHits fills Highlights for the returned hits. Callers hold the read
+// lock.
+func (idx *InMemoryIndex) highlightHits(hits []SearchResponse, trace *searchTrace, cfg HighlightConfig) {
+	encode := func(term string) []string {
+		return idx.phoneticKeys(DefaultField, term)
+	}
+
+	for i := range hits {
+		id := internalIDFor(hits[i].ID)
+		doc, ok := idx.documents[id]
+		if !ok {
+			continue
+		}
+		hits[i].Highlights = highlight(doc.Fields[DefaultField], idx.positions[id], trace.matcher(id, encode, idx.ngramsFor(DefaultField)), cfg)
+	}
+}
@@ -1,8 +1,12 @@
 package index
 
 import (
+	"context"
 	"encoding/gob"
+	"errors"
+	"fmt"
 	"hash/fnv"
+	"io"
 	"log"
 	"maps"
 	"os"
@@ -10,9 +14,12 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/shramanb113/ZENITH/internal/analysis"
+	"github.com/shramanb113/ZENITH/internal/core"
 )
 
 type InMemoryIndex struct {
@@ -26,25 +33,86 @@ type InMemoryIndex struct {
 	globalSeen   map[string]bool
 	wordVectors  map[string][]float32
 	docFragments map[uint32][]string // Tracks fragments for idempotency
+	documents    map[uint32]*core.Document
+	positions    map[uint32][]analysis.Token // stored offsets, used for highlighting
+	docTokens    map[uint32][]string         // terms counted for each document, taken back when it is replaced or deleted
+	deleted      map[uint32]int64            // last version of deleted documents, so a re-added one does not reuse it
+	embedder     analysis.Embedder
+	pipelineCfg  PipelineConfig
+	pipeline     *pipeline
+
+	surfaceCounts map[string]int    // lower-cased words as written, for Suggest
+	surfaceTerms  map[string]string // written word -> indexed term
+	tokenTotal    int               // sum of tokenCounts
+	suggest       atomic.Pointer[suggester]
+	suggestDirty  atomic.Bool
+	suggestBuild  sync.Mutex // held while the dictionaries are rebuilt
+
+	semanticBudget time.Duration
+	fusion         FusionConfig
+	fuzziness      analysis.Fuzziness
+	editCosts      analysis.EditCosts
+	phonetic       map[string][]analysis.PhoneticEncoder // by field
+	analyzers      map[string]fieldAnalyzers
+	namedAnalyzers map[string]*analysis.Analyzer // from the index config, by name
+
+	languageAnalyzers map[string]map[string]fieldAnalyzers // by field, then language
+	fieldLanguages    map[string]string
+	detector          *analysis.LanguageDetector
+	detectMargin      float64
+
+	synonymPrecedence SynonymPrecedence
+
+	ngrams map[string]NGramConfig // by field
+
+	schema        atomic.Pointer[schemaState] // nil: any field is accepted
+	pendingSchema *Schema                     // from WithSchema, installed once analyzers are known
+
+	terms            []string // sorted term dictionary for pattern clauses
+	termsDirty       bool
+	maxExpansions    int
+	expansionTimeout time.Duration
 }
 
+// MinGram and MaxGram bound the edge n-grams of fields without an
+// NGramConfig.
 const (
 	MinGram = 3
 	MaxGram = 10
 )
 
+// DefaultField is the field that holds the raw text sent through IndexDocuments.
+const DefaultField = "data"
+
 type synonymCandidate struct {
 	word  string
 	score float32
 }
 
 type SearchResponse struct {
-	ID    string
-	Score float64
+	ID          string
+	Score       float64
+	Explanation *Explanation // only set when the search asked for it
+	Highlights  []string     // best passage first, only set when asked for
+}
+
+func internalIDFor(originalID string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(originalID))
+	return h.Sum32()
 }
 
-func NewInMemoryIndex() *InMemoryIndex {
-	return &InMemoryIndex{
+type Option func(*InMemoryIndex)
+
+// WithEmbedder replaces the default nerve-backed embedder.
+func WithEmbedder(e analysis.Embedder) Option {
+	return func(idx *InMemoryIndex) {
+		idx.embedder = e
+	}
+}
+
+func NewInMemoryIndex(opts ...Option) *InMemoryIndex {
+	idx := &InMemoryIndex{
 		data:         make(map[string][]uint32),
 		idMapping:    make(map[uint32]string),
 		vectors:      make(map[uint32][]float32),
@@ -54,31 +122,169 @@ func NewInMemoryIndex() *InMemoryIndex {
 		globalSeen:   make(map[string]bool),
 		wordVectors:  make(map[string][]float32),
 		docFragments: make(map[uint32][]string),
+		documents:    make(map[uint32]*core.Document),
+		positions:    make(map[uint32][]analysis.Token),
+		docTokens:    make(map[uint32][]string),
+		deleted:      make(map[uint32]int64),
+
+		surfaceCounts: make(map[string]int),
+		surfaceTerms:  make(map[string]string),
+		analyzers:     make(map[string]fieldAnalyzers),
+
+		languageAnalyzers: make(map[string]map[string]fieldAnalyzers),
+		fieldLanguages:    make(map[string]string),
+		ngrams:            make(map[string]NGramConfig),
 	}
+
+	idx.fuzziness = analysis.AutoFuzziness
+	idx.editCosts = analysis.TypoCosts
+	idx.synonymPrecedence = SynonymsFirst
+	idx.maxExpansions = DefaultMaxExpansions
+	idx.expansionTimeout = DefaultExpansionTimeout
+	idx.phonetic = map[string][]analysis.PhoneticEncoder{
+		DefaultField: {analysis.SoundexEncoder},
+	}
+
+	for _, opt := range opts {
+		opt(idx)
+	}
+	if idx.pendingSchema != nil {
+		idx.installSchema(*idx.pendingSchema)
+		idx.pendingSchema = nil
+	}
+	if idx.embedder == nil {
+		idx.embedder = analysis.NewNerveEmbedder(analysis.NerveConfig{})
+	}
+	idx.startPipeline()
+
+	return idx
 }
 
 /* internal counter is for easier mapping of any document id to just a integer and the data holds the words and the slice of document id ( which is internalcounter) appearing on*/
-func (idx *InMemoryIndex) Add(originalID string, fullText string, tokens []string) {
+// Add makes the document searchable lexically right away and marks it Pending;
+// its vectors are fetched by the embedding pipeline in the background. The
+// text is run through the field's index analyzer. A document that does not
+// fit the schema is rejected with ErrInvalidDocument.
+func (idx *InMemoryIndex) Add(originalID string, fullText string, opts ...AddOption) error {
+
+	internalID := internalIDFor(originalID)
+	doc := &core.Document{
+		ID:     originalID,
+		Fields: map[string]string{DefaultField: fullText},
+		Status: core.TypePending,
+	}
+	for _, opt := range opts {
+		opt(doc)
+	}
+	if err := idx.validateDocument(doc); err != nil {
+		return err
+	}
+	idx.detectFields(doc)
+	analyzed := idx.analyzersFor(DefaultField, languageOf(doc)).index.Analyze(fullText)
 
-	docVec, _ := analysis.GetEmbedding(fullText)
-	tempWordVectors := make(map[string][]float32)
-	for _, t := range tokens {
+	tokens := make([]string, len(analyzed))
+	for i, t := range analyzed {
+		tokens[i] = t.Term
+	}
+	fieldTerms := idx.fieldTerms(doc)
 
-		_, exists := tempWordVectors[t]
-		if !idx.HasWordVector(t) && !exists {
-			vec := idx.RegisterWordVector(t)
-			tempWordVectors[t] = vec
+	idx.mu.Lock()
+
+	// Idempotency: Remove previous entries if document already exists
+	idx.removeFragments(internalID)
+	idx.forgetTokens(internalID)
+
+	idx.idMapping[internalID] = originalID
+
+	// The previous vector (if any) keeps serving until the new one lands.
+	var version int64 = 1
+	if last, ok := idx.deleted[internalID]; ok {
+		version = last + 1
+		delete(idx.deleted, internalID)
+	}
+	if old, ok := idx.documents[internalID]; ok {
+		version = old.Version + 1
+		idx.countSurface(old.Fields[DefaultField], idx.positions[internalID], -1)
+	}
+	doc.Version = version
+	idx.documents[internalID] = doc
+	idx.positions[internalID] = analyzed
+	idx.countSurface(fullText, analyzed, 1)
+
+	seenInDoc := make(map[string]bool)
+	docFrags := []string{}
+	post := func(field string, terms []string) {
+		for _, token := range terms {
+			for _, frag := range idx.postingKeys(field, token) {
+				if seenInDoc[frag] {
+					continue
+				}
+				seenInDoc[frag] = true
+				idx.data[frag] = append(idx.data[frag], internalID)
+				docFrags = append(docFrags, frag)
+			}
+
+			for _, key := range idx.phoneticKeys(field, token) {
+				key = fieldKey(field, key)
+				if seenInDoc[key] {
+					continue
+				}
+				idx.phoneticData[key] = append(idx.phoneticData[key], internalID)
+				seenInDoc[key] = true
+				docFrags = append(docFrags, key)
+			}
 		}
 	}
+	post(DefaultField, tokens)
+	for field, terms := range fieldTerms {
+		post(field, terms)
+	}
 
-	h := fnv.New32a()
-	h.Write([]byte(originalID))
-	internalID := uint32(h.Sum32())
+	// Term statistics describe the data field, which queries search.
+	for _, token := range tokens {
+		idx.tokenCounts[token]++
+		idx.tokenTotal++
+		if !idx.globalSeen[token] {
+			L := utf8.RuneCountInString(token)
+			idx.vocabulary[L] = append(idx.vocabulary[L], token)
+			idx.globalSeen[token] = true
+			idx.termsDirty = true
+		}
+	}
+	idx.docFragments[internalID] = docFrags
+	idx.docTokens[internalID] = tokens
+	idx.mu.Unlock()
+
+	idx.enqueueEmbedding(embedJob{
+		id:      internalID,
+		version: version,
+		text:    fullText,
+		tokens:  tokens,
+	})
+	return nil
+}
 
-	idx.mu.Lock()
-	defer idx.mu.Unlock()
+// postingKeys are the keys a term of field is indexed under in data: the
+// term, its edge and infix n-grams and, in the data field, the whole-term
+// key pattern clauses use.
+func (idx *InMemoryIndex) postingKeys(field, token string) []string {
+	ngrams := idx.fieldNGrams(field)
+	keys := ngrams.edge(token)
+	if field == DefaultField {
+		keys = append(keys, termKey(token))
+	}
+	for _, gram := range ngrams.infix(token) {
+		keys = append(keys, infixKey(gram))
+	}
+	for i, key := range keys {
+		keys[i] = fieldKey(field, key)
+	}
+	return keys
+}
 
-	// Idempotency: Remove previous entries if document already exists
+// removeFragments takes the document out of every posting list it was
+// added to. The caller holds the write lock.
+func (idx *InMemoryIndex) removeFragments(internalID uint32) {
 	if oldFrags, exists := idx.docFragments[internalID]; exists {
 		for _, frag := range oldFrags {
 			if idList, ok := idx.data[frag]; ok {
@@ -102,66 +308,163 @@ func (idx *InMemoryIndex) Add(originalID string, fullText string, tokens []strin
 			}
 		}
 	}
+	delete(idx.docFragments, internalID)
+}
 
-	idx.idMapping[internalID] = originalID
-	idx.vectors[internalID] = docVec
-	maps.Copy(idx.wordVectors, tempWordVectors)
+// forgetTokens takes the document's terms out of the term statistics, and
+// out of the vocabulary once no document has them. The caller holds the
+// write lock.
+func (idx *InMemoryIndex) forgetTokens(internalID uint32) {
+	for _, token := range idx.docTokens[internalID] {
+		idx.tokenTotal--
+		if idx.tokenCounts[token]--; idx.tokenCounts[token] > 0 {
+			continue
+		}
+		delete(idx.tokenCounts, token)
+		delete(idx.globalSeen, token)
+		L := utf8.RuneCountInString(token)
+		idx.vocabulary[L] = slices.DeleteFunc(idx.vocabulary[L], func(t string) bool { return t == token })
+		idx.termsDirty = true
+	}
+	delete(idx.docTokens, internalID)
+}
 
-	seenInDoc := make(map[string]bool)
-	docFrags := []string{}
+// Delete removes the document, returning ErrDocumentNotFound if there is
+// none with that ID.
+func (idx *InMemoryIndex) Delete(originalID string) error {
+	internalID := internalIDFor(originalID)
 
-	for _, token := range tokens {
-		idx.tokenCounts[token]++
-		fragments := generateEdgeNgrams(token)
+	idx.mu.Lock()
+	doc, ok := idx.documents[internalID]
+	if !ok {
+		idx.mu.Unlock()
+		return ErrDocumentNotFound
+	}
+	idx.removeFragments(internalID)
+	idx.forgetTokens(internalID)
+	idx.countSurface(doc.Fields[DefaultField], idx.positions[internalID], -1)
+	delete(idx.documents, internalID)
+	delete(idx.positions, internalID)
+	delete(idx.idMapping, internalID)
+	delete(idx.vectors, internalID)
+	idx.deleted[internalID] = doc.Version
+	idx.mu.Unlock()
+
+	idx.forgetEmbedding(internalID)
+	return nil
+}
 
-		for _, frag := range fragments {
-			if seenInDoc[frag] {
-				continue
-			}
-			seenInDoc[frag] = true
-			idx.data[frag] = append(idx.data[frag], internalID)
-			docFrags = append(docFrags, frag)
-		}
+// Update indexes the document again with the given changes: an empty text
+// keeps the stored one, and the options are applied over the stored fields
+// and metadata. Fields that were not stored are lost.
+func (idx *InMemoryIndex) Update(originalID string, text string, opts ...AddOption) error {
+	storedText, stored, ok := idx.storedDocument(originalID)
+	if !ok {
+		return ErrDocumentNotFound
+	}
+	if text == "" {
+		text = storedText
+	}
 
-		phon := analysis.Soundex(token)
-		if phon != "" && !seenInDoc[phon] {
-			idx.phoneticData[phon] = append(idx.phoneticData[phon], internalID)
-			seenInDoc[phon] = true
-			docFrags = append(docFrags, phon)
+	changes := &core.Document{Fields: make(map[string]string)}
+	for _, opt := range opts {
+		opt(changes)
+	}
+	overlay := func(doc *core.Document) {
+		maps.Copy(doc.Fields, changes.Fields)
+		if changes.Metadata != nil && doc.Metadata == nil {
+			doc.Metadata = make(map[string]interface{})
 		}
+		maps.Copy(doc.Metadata, changes.Metadata)
+	}
+	return idx.Add(originalID, text, append(stored, overlay)...)
+}
 
-		if !idx.globalSeen[token] {
-			L := len(token)
-			idx.vocabulary[L] = append(idx.vocabulary[L], token)
-			idx.globalSeen[token] = true
+// Search ranks documents for the query. The semantic leg (query embedding and
+// neural expansion) runs under the latency budget; if the embedder fails or
+// the budget runs out, ranking falls back to the lexical leg alone and the
+// result says so.
+func (idx *InMemoryIndex) Search(ctx context.Context, query string, opts SearchOptions) SearchResults {
+	var results SearchResults
+	text, fields := idx.parseFieldClauses(query, opts.Language)
+	// Malformed patterns match nothing; ValidateQuery reports them.
+	text, patterns, err := parseTermPatterns(text)
+	if err != nil {
+		return results
+	}
+	expanded, truncated := idx.expandPatterns(patterns)
+	results.ExpansionTruncated = truncated
+
+	if opts.Language == "" {
+		opts.Language = idx.detectLanguage(DefaultField, text)
+	}
+	results.Language = opts.Language
+	clauses := queryClauses(idx.analyzersFor(DefaultField, opts.Language).search.Analyze(text))
+	queryTokens := clauseTerms(clauses)
+	fusion := idx.fusionFor(opts)
+	fuzziness := idx.fuzziness
+	if opts.Fuzziness != nil {
+		fuzziness = *opts.Fuzziness
+	}
+	traced := queryTokens
+	for _, p := range patterns {
+		traced = append(traced, p.raw)
+	}
+	for _, c := range fields {
+		traced = append(traced, c.raw)
+	}
+	trace := newSearchTrace(opts.Explain || opts.Highlight != nil, traced)
+
+	semCtx, cancel := idx.semanticContext(ctx)
+	defer cancel()
+
+	var queryVec []float32
+	if text != "" {
+		queryVec, err = idx.embedder.Embed(semCtx, text)
+		if err != nil {
+			results.skipSemantic(err)
 		}
 	}
-	idx.docFragments[internalID] = docFrags
-}
 
-func (idx *InMemoryIndex) Search(query string, queryTokens []string) []SearchResponse {
 	idx.mu.RLock()
 
-	queryVec, _ := analysis.GetEmbedding(query)
 	keywordScores := make(map[uint32]float64)
 	matchTokens := make(map[uint32]map[string]bool) // Tracks which unique query tokens hit
+	ngrams := idx.ngramsFor(DefaultField)
 
 	// --- Pass 1: Lexical, Phonetic, and Fuzzy ---
 	for _, token := range queryTokens {
 		Q := len(token)
 
-		// 1. N-Grams
-		var searchFragments []string
-		if Q >= 3 {
-			searchFragments = generateEdgeNgrams(token)
-		} else {
-			searchFragments = []string{token}
-		}
-
-		for _, frag := range searchFragments {
+		// 1. N-Grams, the same ones the field was indexed with
+		for _, frag := range ngrams.edge(token) {
 			if ids, ok := idx.data[frag]; ok {
 				for _, id := range ids {
-					keywordScores[id] += (float64(len(frag)) / float64(Q)) * 100.0
+					score := (float64(len(frag)) / float64(Q)) * 100.0
+					keywordScores[id] += score
+					trace.match(id, token, matchFragment, frag, "", score)
+					if matchTokens[id] == nil {
+						matchTokens[id] = make(map[string]bool)
+					}
+					matchTokens[id][token] = true
+				}
+			}
+		}
+
+		// Infix n-grams: a document term containing every gram of the token
+		// likely contains the token.
+		if grams := ngrams.infixQuery(token); len(grams) > 0 {
+			hits := make(map[uint32]int)
+			for _, gram := range grams {
+				for _, id := range idx.data[infixKey(gram)] {
+					hits[id]++
+				}
+			}
+			for id, n := range hits {
+				score := 50.0 * float64(n) / float64(len(grams))
+				keywordScores[id] += score
+				trace.match(id, token, matchInfix, token, fmt.Sprintf("%d of %d grams", n, len(grams)), score)
+				if n == len(grams) {
 					if matchTokens[id] == nil {
 						matchTokens[id] = make(map[string]bool)
 					}
@@ -171,10 +474,16 @@ func (idx *InMemoryIndex) Search(query string, queryTokens []string) []SearchRes
 		}
 
 		// 2. Phonetic
-		phon := analysis.Soundex(token)
-		if ids, ok := idx.phoneticData[phon]; ok {
-			for _, id := range ids {
+		// Several encoders may agree on a document; it still counts once.
+		phoneticHit := make(map[uint32]bool)
+		for _, key := range idx.phoneticKeys(DefaultField, token) {
+			for _, id := range idx.phoneticData[key] {
+				if phoneticHit[id] {
+					continue
+				}
+				phoneticHit[id] = true
 				keywordScores[id] += 50.0
+				trace.match(id, token, matchPhonetic, key, "", 50.0)
 				if matchTokens[id] == nil {
 					matchTokens[id] = make(map[string]bool)
 				}
@@ -182,22 +491,28 @@ func (idx *InMemoryIndex) Search(query string, queryTokens []string) []SearchRes
 			}
 		}
 
-		// 3. Fuzzy (Levenshtein)
-		if Q > 3 {
-			minL, maxL := Q-1, Q+1
-			for size, list := range idx.vocabulary {
-				if size >= minL && size <= maxL {
-					for _, candidate := range list {
-						if dist, ok := analysis.Levenshtein(token, candidate); ok && dist > 0 && dist <= 2 {
-							if ids, exists := idx.data[candidate]; exists {
-								for _, id := range ids {
-									keywordScores[id] += 60.0 / float64(dist)
-									if matchTokens[id] == nil {
-										matchTokens[id] = make(map[string]bool)
-									}
-									matchTokens[id][token] = true
-								}
+		// 3. Fuzzy (Damerau-Levenshtein)
+		runes := utf8.RuneCountInString(token)
+		if budget := fuzziness.MaxEdits(runes); budget > 0 {
+			spread := lengthSpread(budget, idx.editCosts)
+			for size := runes - spread; size <= runes+spread; size++ {
+				for _, candidate := range idx.vocabulary[size] {
+					if candidate == token {
+						continue
+					}
+					dist, ok := analysis.DamerauLevenshtein(token, candidate, idx.editCosts, budget)
+					if !ok || dist == 0 {
+						continue
+					}
+					if ids, exists := idx.data[termKey(candidate)]; exists {
+						score := fuzzyScore(dist)
+						for _, id := range ids {
+							keywordScores[id] += score
+							trace.match(id, token, matchFuzzy, candidate, fmt.Sprintf("distance %g", dist), score)
+							if matchTokens[id] == nil {
+								matchTokens[id] = make(map[string]bool)
 							}
+							matchTokens[id][token] = true
 						}
 					}
 				}
@@ -205,37 +520,76 @@ func (idx *InMemoryIndex) Search(query string, queryTokens []string) []SearchRes
 		}
 	}
 
+	// 4. Wildcard and regex clauses score like a whole-term match.
+	for _, p := range patterns {
+		for _, term := range expanded[p] {
+			for _, id := range idx.data[termKey(term)] {
+				keywordScores[id] += 100.0
+				trace.match(id, p.raw, matchPattern, term, "", 100.0)
+				if matchTokens[id] == nil {
+					matchTokens[id] = make(map[string]bool)
+				}
+				matchTokens[id][p.raw] = true
+			}
+		}
+	}
+
+	// 5. Field clauses match in their field's own postings.
+	for _, c := range fields {
+		for id, score := range idx.fieldMatches(c) {
+			keywordScores[id] += score
+			trace.match(id, c.raw, matchField, c.field, "", score)
+			if matchTokens[id] == nil {
+				matchTokens[id] = make(map[string]bool)
+			}
+			matchTokens[id][c.raw] = true
+		}
+	}
+
 	// Calculate Vector Scores
 	vectorScores := make(map[uint32]float64)
-	for id, docVec := range idx.vectors {
-		vectorScores[id] = float64(analysis.CosineSimilarity(queryVec, docVec))
+	if queryVec != nil {
+		st := idx.schema.Load()
+		for id, docVec := range idx.vectors {
+			vectorScores[id] = st.similarity(queryVec, docVec)
+		}
 	}
 
 	for id, score := range keywordScores {
 		if score > 0 {
+			results.KeywordHits++
 			keywordScores[id] += 10000.0
-			// CRITICAL FIX: Only award big bonus if ALL unique query tokens matched (Issue 1)
-			if len(matchTokens[id]) >= len(queryTokens) {
+			trace.match(id, "", matchBonus, "", "lexical match bonus", 10000.0)
+			// CRITICAL FIX: Only award big bonus if ALL query clauses matched (Issue 1)
+			if allClausesMatched(clauses, matchTokens[id]) && allPatternsMatched(patterns, matchTokens[id]) && allFieldsMatched(fields, matchTokens[id]) {
 				keywordScores[id] += 50000.0
+				trace.match(id, "", matchBonus, "", "all query tokens matched bonus", 50000.0)
 			}
 		}
 	}
 
-	searchResponse := idx.finalizeRanks(keywordScores, vectorScores)
+	searchResponse := idx.finalizeRanks(keywordScores, vectorScores, fusion, trace)
 
 	// --- Pass 2: Neural Expansion ---
-	if len(searchResponse) == 0 || (len(searchResponse) > 0 && searchResponse[0].Score < 5.0) {
+	if !results.SemanticSkipped && (len(searchResponse) == 0 || (len(searchResponse) > 0 && searchResponse[0].Score < 5.0)) {
 		idx.mu.RUnlock()
-		analyzer := analysis.New()
 
-		for _, token := range queryTokens {
+		for _, token := range idx.neuralCandidates(clauses) {
 			if len(token) < 3 {
 				continue
 			}
 
-			neighbors := idx.GetSemanticNeighbors(token, 5, 0.70)
+			neighbors, err := idx.GetSemanticNeighbors(semCtx, token, 5, 0.70)
+			if err != nil {
+				// Keep whatever the tokens before this one contributed.
+				results.skipSemantic(err)
+				break
+			}
 			for _, neighbor := range neighbors {
-				stemmedNeighbor := analyzer.Stem(neighbor)
+				stemmedNeighbor := neighbor
+				if terms := idx.searchTerms(DefaultField, opts.Language, neighbor); len(terms) > 0 {
+					stemmedNeighbor = terms[0]
+				}
 
 				idx.mu.RLock()
 				targets := make(map[uint32]bool)
@@ -244,8 +598,7 @@ func (idx *InMemoryIndex) Search(query string, queryTokens []string) []SearchRes
 						targets[id] = true
 					}
 				}
-				if len(stemmedNeighbor) > 3 {
-					prefix := stemmedNeighbor[:3]
+				if prefix, ok := ngrams.prefix(stemmedNeighbor); ok {
 					if ids, ok := idx.data[prefix]; ok {
 						for _, id := range ids {
 							targets[id] = true
@@ -255,6 +608,7 @@ func (idx *InMemoryIndex) Search(query string, queryTokens []string) []SearchRes
 
 				for id := range targets {
 					keywordScores[id] += 20000.0
+					trace.match(id, token, matchNeighbor, stemmedNeighbor, fmt.Sprintf("expanded from %q", neighbor), 20000.0)
 					if matchTokens[id] == nil {
 						matchTokens[id] = make(map[string]bool)
 					}
@@ -269,25 +623,43 @@ func (idx *InMemoryIndex) Search(query string, queryTokens []string) []SearchRes
 		// RE-RANK with new bonuses
 		for id, score := range keywordScores {
 			if score > 0 {
-				if len(matchTokens[id]) >= len(queryTokens) {
+				if allClausesMatched(clauses, matchTokens[id]) && allPatternsMatched(patterns, matchTokens[id]) && allFieldsMatched(fields, matchTokens[id]) {
 					keywordScores[id] += 50000.0
+					trace.match(id, "", matchBonus, "", "all query tokens matched after expansion bonus", 50000.0)
 				}
 			}
 		}
 
-		searchResponse = idx.finalizeRanks(keywordScores, vectorScores)
+		searchResponse = idx.finalizeRanks(keywordScores, vectorScores, fusion, trace)
+	}
+
+	if len(searchResponse) > maxHits && !opts.unlimited {
+		searchResponse = searchResponse[:maxHits]
 	}
 
-	if len(searchResponse) > 5 {
-		searchResponse = searchResponse[:5]
+	if opts.Explain {
+		metric := idx.schema.Load().metric()
+		for i := range searchResponse {
+			id := internalIDFor(searchResponse[i].ID)
+			searchResponse[i].Explanation = trace.explain(id, searchResponse[i].Score, fusion, metric)
+		}
+	}
+	if opts.Highlight != nil {
+		idx.highlightHits(searchResponse, trace, opts.Highlight.withDefaults())
 	}
 
 	idx.mu.RUnlock()
-	return searchResponse
+	results.Hits = searchResponse
+	return results
 }
 
-func (idx *InMemoryIndex) finalizeRanks(keywordScores map[uint32]float64, vectorScores map[uint32]float64) []SearchResponse {
-	const k = 60.0 // Adjusted to standard RRF constant (Fixes Issue 2)
+func (idx *InMemoryIndex) finalizeRanks(keywordScores map[uint32]float64, vectorScores map[uint32]float64, fusion FusionConfig, trace *searchTrace) []SearchResponse {
+	trace.resetLegs()
+	if fusion.Method != FusionRRF {
+		return idx.fuseScores(keywordScores, vectorScores, fusion, trace)
+	}
+
+	k := fusion.K
 	rrfScores := make(map[uint32]float64)
 
 	keywordIDs := make([]uint32, 0)
@@ -335,14 +707,26 @@ func (idx *InMemoryIndex) finalizeRanks(keywordScores map[uint32]float64, vector
 	// 3. RRF Blending
 	// Boost documents that appear in the keywordIDs (which now includes synonyms)
 	for rank, id := range keywordIDs {
-		rrfScores[id] += (1.0 / (k + float64(rank+1))) * 100.0
+		part := (1.0 / (k + float64(rank+1))) * *fusion.KeywordWeight
+		rrfScores[id] += part
+		if leg := trace.leg(id); leg != nil {
+			leg.keywordRank, leg.keywordRaw, leg.keywordPart = rank+1, keywordScores[id], part
+		}
 	}
 	for rank, id := range vectorIDs {
-		rrfScores[id] += (1.0 / (k + float64(rank+1)))
+		part := (1.0 / (k + float64(rank+1))) * *fusion.VectorWeight
+		rrfScores[id] += part
+		if leg := trace.leg(id); leg != nil {
+			leg.vectorRank, leg.vectorRaw, leg.vectorPart = rank+1, vectorScores[id], part
+		}
 	}
 
-	results := make([]SearchResponse, 0, len(rrfScores))
-	for id, score := range rrfScores {
+	return idx.sortedResponses(rrfScores)
+}
+
+func (idx *InMemoryIndex) sortedResponses(scores map[uint32]float64) []SearchResponse {
+	results := make([]SearchResponse, 0, len(scores))
+	for id, score := range scores {
 		results = append(results, SearchResponse{
 			ID:    idx.idMapping[id],
 			Score: score,
@@ -427,6 +811,8 @@ func (idx *InMemoryIndex) Save(filepath string) error {
 		idx.data, idx.idMapping, idx.vectors,
 		idx.tokenCounts, idx.phoneticData, idx.vocabulary,
 		idx.globalSeen, idx.wordVectors, idx.docFragments,
+		idx.documents, idx.positions, idx.schemaSnapshot(),
+		idx.docTokens,
 	}
 
 	for _, s := range state {
@@ -439,7 +825,17 @@ func (idx *InMemoryIndex) Save(filepath string) error {
 	return nil
 }
 
+// Load restores a snapshot and resumes embedding for documents that were
+// still Pending when it was written.
 func (idx *InMemoryIndex) Load(filepath string) error {
+	if err := idx.load(filepath); err != nil {
+		return err
+	}
+	idx.requeuePending()
+	return nil
+}
+
+func (idx *InMemoryIndex) load(filepath string) error {
 	start := time.Now()
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
@@ -469,45 +865,60 @@ func (idx *InMemoryIndex) Load(filepath string) error {
 		}
 	}
 
-	log.Printf("Successfully loaded %d internal IDs from disk in %v", len(idx.idMapping), time.Since(start))
-	return nil
-}
-
-func generateEdgeNgrams(token string) []string {
-	runes := []rune(token)
-	n := len(runes)
-
-	if n < MinGram {
-		return []string{token}
+	// Sections added later are optional so older snapshots still load.
+	var schema Schema
+	optional := []any{&idx.documents, &idx.positions, &schema, &idx.docTokens}
+	for _, s := range optional {
+		if err := info.Decode(s); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
 	}
 
-	limit := n
-	if limit > MaxGram {
-		limit = MaxGram
+	// Snapshots written before documents were stored have none; rebuild the
+	// records from what we have, Converted if a vector was already stored.
+	if len(idx.documents) == 0 {
+		for id, originalID := range idx.idMapping {
+			status := core.TypePending
+			if idx.vectors[id] != nil {
+				status = core.TypeConverted
+			}
+			idx.documents[id] = &core.Document{ID: originalID, Version: 1, Status: status}
+		}
 	}
-
-	results := make([]string, 0, limit-MinGram+1)
-	results = append(results, token)
-	for i := MinGram; i < limit; i++ {
-		results = append(results, string(runes[0:i]))
+	// Snapshots without document terms count what the positions still tell.
+	if len(idx.docTokens) == 0 {
+		for id, tokens := range idx.positions {
+			terms := make([]string, len(tokens))
+			for i, t := range tokens {
+				terms[i] = t.Term
+			}
+			idx.docTokens[id] = terms
+		}
 	}
-
-	if n > MaxGram {
-		results = append(results, token)
+	idx.migratePhoneticKeys()
+	idx.migrateTermKeys()
+	if schema.Fields != nil {
+		idx.installSchema(schema)
 	}
 
-	return results
-}
-
-func (idx *InMemoryIndex) RegisterWordVector(word string) []float32 {
-
-	vector, err := analysis.GetEmbedding(word)
-
-	if err != nil {
-		return nil
+	// Older snapshots bucket the vocabulary by bytes rather than runes.
+	idx.vocabulary = make(map[int][]string)
+	for token := range idx.globalSeen {
+		L := utf8.RuneCountInString(token)
+		idx.vocabulary[L] = append(idx.vocabulary[L], token)
+	}
+	idx.rebuildSurfaceCounts()
+	idx.termsDirty = true
+	idx.tokenTotal = 0
+	for _, n := range idx.tokenCounts {
+		idx.tokenTotal += n
 	}
 
-	return vector
+	log.Printf("Successfully loaded %d internal IDs from disk in %v", len(idx.idMapping), time.Since(start))
+	return nil
 }
 
 func (idx *InMemoryIndex) HasWordVector(t string) bool {
@@ -519,10 +930,20 @@ func (idx *InMemoryIndex) HasWordVector(t string) bool {
 	return exists
 }
 
-func (idx *InMemoryIndex) GetSemanticNeighbors(token string, topN int, threshold float32) []string {
-	tokenVec, err := analysis.GetEmbedding(token)
-	if err != nil || tokenVec == nil {
-		return []string{}
+func (idx *InMemoryIndex) GetSemanticNeighbors(ctx context.Context, token string, topN int, threshold float32) ([]string, error) {
+	idx.mu.RLock()
+	tokenVec, known := idx.wordVectors[token]
+	idx.mu.RUnlock()
+
+	if !known {
+		var err error
+		tokenVec, err = idx.embedder.Embed(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		if tokenVec == nil {
+			return []string{}, nil
+		}
 	}
 
 	idx.mu.RLock()
@@ -549,7 +970,7 @@ func (idx *InMemoryIndex) GetSemanticNeighbors(token string, topN int, threshold
 	}
 
 	if len(candidates) == 0 {
-		return []string{}
+		return []string{}, nil
 	}
 
 	slices.SortFunc(candidates, func(a, b synonymCandidate) int {
@@ -570,5 +991,5 @@ func (idx *InMemoryIndex) GetSemanticNeighbors(token string, topN int, threshold
 		results = append(results, candidates[i].word)
 	}
 
-	return results
+	return results, nil
 }
@@ -0,0 +1,173 @@
+package index
+
+import (
+	"encoding/gob"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+	"github.com/shramanb113/ZENITH/internal/core"
+)
+
+const (
+	// LanguageKey holds the language the document was analyzed as, and
+	// FieldLanguagesKey the language detected for each field.
+	LanguageKey       = "language"
+	FieldLanguagesKey = "field_languages"
+
+	// DefaultDetectMargin is the detection margin needed to analyze text as
+	// another language than its field's; short technical phrases share too
+	// many n-grams across languages to trust a narrow win.
+	DefaultDetectMargin = 0.25
+)
+
+// The detected languages sit in Metadata, which snapshots encode as
+// interface values.
+func init() {
+	gob.Register(map[string]string{})
+}
+
+// WithLanguage analyzes the document with its language's analyzer instead
+// of the field default. lang is a name or ISO 639-1 code accepted by
+// analysis.CanonicalLanguage; callers validate it first.
+func WithLanguage(lang string) AddOption {
+	return func(doc *core.Document) {
+		name, err := analysis.CanonicalLanguage(lang)
+		if lang == "" || err != nil {
+			return
+		}
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]interface{})
+		}
+		doc.Metadata[LanguageKey] = name
+	}
+}
+
+// WithFieldLanguage sets the language a field is written in when nothing
+// else is known. It defaults to English, the language of the standard
+// analyzer.
+func WithFieldLanguage(field, lang string) Option {
+	return func(idx *InMemoryIndex) {
+		if name, err := analysis.CanonicalLanguage(lang); err == nil {
+			idx.fieldLanguages[field] = name
+		}
+	}
+}
+
+// WithLanguageDetection detects the language of untagged documents and
+// queries with d, switching away from the field's language only when the
+// detection wins by minMargin.
+func WithLanguageDetection(d *analysis.LanguageDetector, minMargin float64) Option {
+	return func(idx *InMemoryIndex) {
+		idx.detector = d
+		idx.detectMargin = minMargin
+	}
+}
+
+func (idx *InMemoryIndex) fieldLanguage(field string) string {
+	if lang, ok := idx.fieldLanguages[field]; ok {
+		return lang
+	}
+	return "english"
+}
+
+// detectLanguage returns the language text should be analyzed as in field,
+// or "" if detection is off or unsure.
+func (idx *InMemoryIndex) detectLanguage(field, text string) string {
+	if idx.detector == nil {
+		return ""
+	}
+	lang, margin := idx.detector.Detect(text)
+	if lang != idx.fieldLanguage(field) && margin < idx.detectMargin {
+		return ""
+	}
+	return lang
+}
+
+// detectFields records the detected language of every field of doc and,
+// unless the caller tagged one, analyzes it as its default field's.
+func (idx *InMemoryIndex) detectFields(doc *core.Document) {
+	if idx.detector == nil {
+		return
+	}
+	detected := make(map[string]string)
+	for field, text := range doc.Fields {
+		if lang := idx.detectLanguage(field, text); lang != "" {
+			detected[field] = lang
+		}
+	}
+	if len(detected) == 0 {
+		return
+	}
+
+	if doc.Metadata == nil {
+		doc.Metadata = make(map[string]interface{})
+	}
+	doc.Metadata[FieldLanguagesKey] = detected
+	if lang, ok := detected[DefaultField]; ok && languageOf(doc) == "" {
+		doc.Metadata[LanguageKey] = lang
+	}
+}
+
+func languageOf(doc *core.Document) string {
+	lang, _ := doc.Metadata[LanguageKey].(string)
+	return lang
+}
+
+// fieldLanguageOf is the language field is analyzed as: the one detected
+// for it, else the document's.
+func fieldLanguageOf(doc *core.Document, field string) string {
+	detected, _ := doc.Metadata[FieldLanguagesKey].(map[string]string)
+	if lang := detected[field]; lang != "" {
+		return lang
+	}
+	return languageOf(doc)
+}
+
+// WithLanguageAnalyzers sets the analyzers a field uses for documents and
+// queries in the given language, overriding the built-in language analyzer.
+func WithLanguageAnalyzers(field, lang string, indexAnalyzer, searchAnalyzer *analysis.Analyzer) Option {
+	return func(idx *InMemoryIndex) {
+		if idx.languageAnalyzers[field] == nil {
+			idx.languageAnalyzers[field] = make(map[string]fieldAnalyzers)
+		}
+		idx.languageAnalyzers[field][lang] = fieldAnalyzers{index: indexAnalyzer, search: searchAnalyzer}
+	}
+}
+
+// analyzersFor picks the analyzers of field for a language, falling back to
+// the field's own when lang is empty, unknown or the field's language.
+func (idx *InMemoryIndex) analyzersFor(field, lang string) fieldAnalyzers {
+	if lang != "" {
+		if name, err := analysis.CanonicalLanguage(lang); err == nil {
+			if a, ok := idx.languageAnalyzers[field][name]; ok {
+				return a
+			}
+			if name == idx.fieldLanguage(field) {
+				return idx.fieldAnalyzers(field)
+			}
+			if a, ok := idx.namedAnalyzers[name]; ok {
+				return fieldAnalyzers{index: a, search: a}
+			}
+			if a, ok := builtinAnalyzers[name]; ok {
+				return fieldAnalyzers{index: a, search: a}
+			}
+		}
+	}
+	return idx.fieldAnalyzers(field)
+}
+
+func (idx *InMemoryIndex) fieldAnalyzers(field string) fieldAnalyzers {
+	if st := idx.schema.Load(); st != nil {
+		if a, ok := st.analyzers[field]; ok {
+			return a
+		}
+	}
+	return idx.configAnalyzers(field)
+}
+
+// configAnalyzers are the analyzers the index configuration gives field.
+func (idx *InMemoryIndex) configAnalyzers(field string) fieldAnalyzers {
+	if a, ok := idx.analyzers[field]; ok {
+		return a
+	}
+	return fieldAnalyzers{index: standardAnalyzer, search: standardAnalyzer}
+}
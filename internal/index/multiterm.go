@@ -0,0 +1,344 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+const (
+	// DefaultMaxExpansions caps the terms one wildcard or regex clause may
+	// expand to.
+	DefaultMaxExpansions = 128
+	// DefaultExpansionTimeout caps the time all pattern clauses of a query
+	// spend walking the term dictionary.
+	DefaultExpansionTimeout = 50 * time.Millisecond
+
+	maxPatternLength = 256
+)
+
+var ErrInvalidPattern = errors.New("invalid term pattern")
+
+// termPrefix namespaces whole-term postings in data. The plain key of a
+// term also lists the documents it is only an edge n-gram of, which a
+// pattern clause must not match. Analyzers never leave a control character
+// in a term, so the key cannot collide with one.
+const termPrefix = "\x01"
+
+func termKey(term string) string {
+	return termPrefix + term
+}
+
+// migrateTermKeys adds the whole-term postings snapshots written before
+// they existed lack. The caller holds the write lock.
+func (idx *InMemoryIndex) migrateTermKeys() {
+	for key := range idx.data {
+		if strings.HasPrefix(key, termPrefix) {
+			return
+		}
+	}
+	for id, tokens := range idx.docTokens {
+		seen := make(map[string]bool)
+		for _, token := range tokens {
+			key := termKey(token)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			idx.data[key] = append(idx.data[key], id)
+			idx.docFragments[id] = append(idx.docFragments[id], key)
+		}
+	}
+}
+
+// WithExpansionLimits bounds wildcard and regex expansion: at most
+// maxTerms terms per clause and timeout per query (0 means no limit).
+func WithExpansionLimits(maxTerms int, timeout time.Duration) Option {
+	return func(idx *InMemoryIndex) {
+		idx.maxExpansions = maxTerms
+		idx.expansionTimeout = timeout
+	}
+}
+
+// termPattern is a wildcard (pag?rank, *rank) or /regex/ query clause. It
+// must match a whole indexed term.
+type termPattern struct {
+	raw  string
+	prog *syntax.Prog
+}
+
+// A regex clause is a whitespace-delimited /.../; a wildcard clause is any
+// other word with * or ?, except a word whose only wildcard is a trailing ?,
+// which is read as a question.
+var regexClause = regexp.MustCompile(`(?:^|\s)/((?:\\.|[^/\\])+)/(?:\s|$)`)
+
+// parseTermPatterns pulls the pattern clauses out of the query and returns
+// the rest for regular analysis.
+func parseTermPatterns(query string) (string, []*termPattern, error) {
+	var patterns []*termPattern
+	var rest strings.Builder
+
+	last := 0
+	for _, m := range regexClause.FindAllStringSubmatchIndex(query, -1) {
+		p, err := compilePattern(query[m[2]-1:m[3]+1], query[m[2]:m[3]])
+		if err != nil {
+			return "", nil, err
+		}
+		patterns = append(patterns, p)
+		rest.WriteString(query[last:m[0]])
+		rest.WriteByte(' ')
+		last = m[1]
+	}
+	rest.WriteString(query[last:])
+
+	var words []string
+	for _, word := range strings.Fields(rest.String()) {
+		if !strings.ContainsAny(strings.TrimSuffix(word, "?"), "*?") {
+			words = append(words, word)
+			continue
+		}
+		p, err := compilePattern(word, wildcardToRegexp(word))
+		if err != nil {
+			return "", nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	return strings.Join(words, " "), patterns, nil
+}
+
+// wildcardToRegexp translates * and ?, folding the literal parts the way
+// the standard analyzer folds indexed terms.
+func wildcardToRegexp(word string) string {
+	var b strings.Builder
+	literal := func(s string) {
+		b.WriteString(regexp.QuoteMeta(analysis.FoldDiacritics(strings.ToLower(s))))
+	}
+	start := 0
+	for i, r := range word {
+		switch r {
+		case '*':
+			literal(word[start:i])
+			b.WriteString(".*")
+			start = i + 1
+		case '?':
+			literal(word[start:i])
+			b.WriteString(".")
+			start = i + 1
+		}
+	}
+	literal(word[start:])
+	return b.String()
+}
+
+func compilePattern(raw, expr string) (*termPattern, error) {
+	if utf8.RuneCountInString(expr) > maxPatternLength {
+		return nil, fmt.Errorf("%w %s: longer than %d characters", ErrInvalidPattern, raw, maxPatternLength)
+	}
+	re, err := syntax.Parse(expr, syntax.Perl|syntax.FoldCase)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrInvalidPattern, raw, err)
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrInvalidPattern, raw, err)
+	}
+	return &termPattern{raw: raw, prog: prog}, nil
+}
+
+// ValidateQuery reports malformed wildcard or regex clauses, which Search
+// would otherwise treat as matching nothing.
+func ValidateQuery(query string) error {
+	_, _, err := parseTermPatterns(query)
+	return err
+}
+
+// nfaState is the set of program counters a pattern can be in after some
+// prefix of a term; empty means no term with that prefix can match.
+type nfaState []uint32
+
+func (p *termPattern) start() nfaState {
+	var s nfaState
+	seen := make(map[uint32]bool)
+	p.add(&s, seen, uint32(p.prog.Start), true)
+	return s
+}
+
+// add follows the instructions that consume no input. Assertions at the
+// end of the term are kept in the set and checked by accepts.
+func (p *termPattern) add(s *nfaState, seen map[uint32]bool, pc uint32, atStart bool) {
+	if seen[pc] {
+		return
+	}
+	seen[pc] = true
+	inst := &p.prog.Inst[pc]
+	switch inst.Op {
+	case syntax.InstAlt, syntax.InstAltMatch:
+		p.add(s, seen, inst.Out, atStart)
+		p.add(s, seen, inst.Arg, atStart)
+	case syntax.InstCapture, syntax.InstNop:
+		p.add(s, seen, inst.Out, atStart)
+	case syntax.InstEmptyWidth:
+		switch empty := syntax.EmptyOp(inst.Arg); {
+		case empty&(syntax.EmptyBeginText|syntax.EmptyBeginLine) != 0:
+			if atStart {
+				p.add(s, seen, inst.Out, atStart)
+			}
+		case empty&(syntax.EmptyEndText|syntax.EmptyEndLine) != 0:
+			*s = append(*s, pc)
+		default:
+			// Word boundaries are not meaningful inside a single term.
+			p.add(s, seen, inst.Out, atStart)
+		}
+	case syntax.InstFail:
+	default:
+		*s = append(*s, pc)
+	}
+}
+
+func (p *termPattern) step(s nfaState, r rune) nfaState {
+	var next nfaState
+	seen := make(map[uint32]bool)
+	for _, pc := range s {
+		inst := &p.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstRune, syntax.InstRune1:
+			if inst.MatchRune(r) {
+				p.add(&next, seen, inst.Out, false)
+			}
+		case syntax.InstRuneAny:
+			p.add(&next, seen, inst.Out, false)
+		case syntax.InstRuneAnyNotNL:
+			if r != '\n' {
+				p.add(&next, seen, inst.Out, false)
+			}
+		}
+	}
+	return next
+}
+
+func (p *termPattern) accepts(s nfaState) bool {
+	for _, pc := range s {
+		switch inst := &p.prog.Inst[pc]; inst.Op {
+		case syntax.InstMatch:
+			return true
+		case syntax.InstEmptyWidth:
+			var rest nfaState
+			p.add(&rest, map[uint32]bool{pc: true}, inst.Out, false)
+			if p.accepts(rest) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expand intersects the pattern with the sorted term dictionary. The walk
+// keeps one state per prefix rune, reuses the states of the prefix shared
+// with the previous term, and skips every term under a prefix the pattern
+// has already rejected. It stops at limit terms or the deadline and reports
+// whether it did.
+func (p *termPattern) expand(terms []string, limit int, deadline time.Time) ([]string, bool) {
+	var out []string
+	states := []nfaState{p.start()}
+	var prev []rune
+
+	for i, visited := 0, 0; i < len(terms); visited++ {
+		if visited%256 == 0 && !deadline.IsZero() && time.Now().After(deadline) {
+			return out, true
+		}
+
+		term := []rune(terms[i])
+		shared := 0
+		for shared < len(prev) && shared < len(term) && prev[shared] == term[shared] {
+			shared++
+		}
+		states = states[:min(len(states), shared+1)]
+
+		dead := -1
+		for d := len(states) - 1; d < len(term); d++ {
+			next := p.step(states[d], term[d])
+			if len(next) == 0 {
+				dead = d + 1
+				break
+			}
+			states = append(states, next)
+		}
+		prev = term
+
+		if dead >= 0 {
+			// Nothing starting with term[:dead] can match.
+			prefix := string(term[:dead])
+			i += sort.SearchStrings(terms[i:], prefix+string(utf8.MaxRune))
+			continue
+		}
+
+		if p.accepts(states[len(term)]) {
+			if limit > 0 && len(out) == limit {
+				return out, true
+			}
+			out = append(out, terms[i])
+		}
+		i++
+	}
+	return out, false
+}
+
+// termDictionary returns the indexed terms in sorted order, rebuilding the
+// list on the first call after a new term was indexed.
+func (idx *InMemoryIndex) termDictionary() []string {
+	idx.mu.RLock()
+	terms, dirty := idx.terms, idx.termsDirty
+	idx.mu.RUnlock()
+	if !dirty && terms != nil {
+		return terms
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.termsDirty || idx.terms == nil {
+		idx.terms = make([]string, 0, len(idx.tokenCounts))
+		for term := range idx.tokenCounts {
+			idx.terms = append(idx.terms, term)
+		}
+		sort.Strings(idx.terms)
+		idx.termsDirty = false
+	}
+	return idx.terms
+}
+
+// expandPatterns expands every pattern clause, sharing one deadline.
+func (idx *InMemoryIndex) expandPatterns(patterns []*termPattern) (map[*termPattern][]string, bool) {
+	if len(patterns) == 0 {
+		return nil, false
+	}
+	terms := idx.termDictionary()
+
+	var deadline time.Time
+	if idx.expansionTimeout > 0 {
+		deadline = time.Now().Add(idx.expansionTimeout)
+	}
+	expanded := make(map[*termPattern][]string, len(patterns))
+	truncated := false
+	for _, p := range patterns {
+		matches, cut := p.expand(terms, idx.maxExpansions, deadline)
+		expanded[p] = matches
+		truncated = truncated || cut
+	}
+	return expanded, truncated
+}
+
+func allPatternsMatched(patterns []*termPattern, matched map[string]bool) bool {
+	for _, p := range patterns {
+		if !matched[p.raw] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package index
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// patternTerms is sorted, as the term dictionary is.
+var patternTerms = []string{"page", "pager", "pagerank", "paging", "rank", "ranking", "spank"}
+
+func TestPatternExpand(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"pag*", []string{"page", "pager", "pagerank", "paging"}},
+		{"pag?r", []string{"pager"}},
+		{"*rank", []string{"pagerank", "rank"}},
+		{"*ank", []string{"pagerank", "rank", "spank"}},
+		{"PAG*", []string{"page", "pager", "pagerank", "paging"}},
+		{"/pag.r/", []string{"pager"}},
+		{"/ank/", nil},
+		{"/^rank$/", []string{"rank"}},
+		{"/rank(ing)?/", []string{"rank", "ranking"}},
+		{"/p[a-z]+ing/", []string{"paging"}},
+		{"zz*", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, patterns, err := parseTermPatterns(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(patterns) != 1 {
+				t.Fatalf("got %d patterns, want 1", len(patterns))
+			}
+			got, truncated := patterns[0].expand(patternTerms, 0, time.Time{})
+			if truncated {
+				t.Error("truncated without a limit")
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatternExpandLimits(t *testing.T) {
+	_, patterns, err := parseTermPatterns("pag*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := patterns[0]
+
+	tests := []struct {
+		name          string
+		limit         int
+		deadline      time.Time
+		want          []string
+		wantTruncated bool
+	}{
+		{"under the limit", 4, time.Time{}, []string{"page", "pager", "pagerank", "paging"}, false},
+		{"at the limit", 2, time.Time{}, []string{"page", "pager"}, true},
+		{"past the deadline", 0, time.Now().Add(-time.Second), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := p.expand(patternTerms, tt.limit, tt.deadline)
+			if !slices.Equal(got, tt.want) || truncated != tt.wantTruncated {
+				t.Errorf("got %q (truncated %v), want %q (truncated %v)", got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestParseTermPatterns(t *testing.T) {
+	tests := []struct {
+		query    string
+		rest     string
+		patterns []string
+		invalid  bool
+	}{
+		{"what is pagerank?", "what is pagerank?", nil, false},
+		{"pag* algorithm", "algorithm", []string{"pag*"}, false},
+		{"/pag.r/ and *rank", "and", []string{"/pag.r/", "*rank"}, false},
+		{"/(unclosed/", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rest, patterns, err := parseTermPatterns(tt.query)
+			if tt.invalid {
+				if !errors.Is(err, ErrInvalidPattern) {
+					t.Fatalf("got error %v, want ErrInvalidPattern", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var raw []string
+			for _, p := range patterns {
+				raw = append(raw, p.raw)
+			}
+			if rest != tt.rest || !slices.Equal(raw, tt.patterns) {
+				t.Errorf("got %q %q, want %q %q", rest, raw, tt.rest, tt.patterns)
+			}
+		})
+	}
+}
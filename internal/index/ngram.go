@@ -0,0 +1,117 @@
+package index
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// NGramConfig sets the partial keys a field's terms are indexed under.
+// Edge n-grams are prefixes of EdgeMin to EdgeMax runes and serve prefix
+// and typo-tolerant matching; infix n-grams are every substring of InfixMin
+// to InfixMax runes and let "rank" find "pagerank". A zero max turns that
+// kind off, leaving whole terms only.
+type NGramConfig struct {
+	EdgeMin  int `json:"edge_min,omitempty"`
+	EdgeMax  int `json:"edge_max,omitempty"`
+	InfixMin int `json:"infix_min,omitempty"`
+	InfixMax int `json:"infix_max,omitempty"`
+}
+
+// DefaultNGrams is what fields without a configuration get: edge n-grams
+// from MinGram to MaxGram runes and no infixes.
+var DefaultNGrams = NGramConfig{EdgeMin: MinGram, EdgeMax: MaxGram}
+
+func (c NGramConfig) validate() error {
+	if c.EdgeMax > 0 && (c.EdgeMin < 1 || c.EdgeMax < c.EdgeMin) {
+		return fmt.Errorf("edge n-gram sizes must satisfy 1 <= edge_min <= edge_max, got %d and %d", c.EdgeMin, c.EdgeMax)
+	}
+	if c.InfixMax > 0 && (c.InfixMin < 1 || c.InfixMax < c.InfixMin) {
+		return fmt.Errorf("infix n-gram sizes must satisfy 1 <= infix_min <= infix_max, got %d and %d", c.InfixMin, c.InfixMax)
+	}
+	return nil
+}
+
+// WithNGrams sets the n-grams field is indexed and queried with.
+func WithNGrams(field string, cfg NGramConfig) Option {
+	return func(idx *InMemoryIndex) {
+		idx.ngrams[field] = cfg
+	}
+}
+
+// ngramsFor is the n-gram configuration of field: the schema's, then the
+// index configuration's, then DefaultNGrams.
+func (idx *InMemoryIndex) ngramsFor(field string) NGramConfig {
+	if st := idx.schema.Load(); st != nil {
+		if f, ok := st.schema.Fields[field]; ok && f.NGrams != nil {
+			return *f.NGrams
+		}
+	}
+	if cfg, ok := idx.ngrams[field]; ok {
+		return cfg
+	}
+	return DefaultNGrams
+}
+
+// edge returns the token itself followed by its prefixes.
+func (c NGramConfig) edge(token string) []string {
+	grams := []string{token}
+	if c.EdgeMax == 0 {
+		return grams
+	}
+	runes := []rune(token)
+	for i := c.EdgeMin; i <= c.EdgeMax && i < len(runes); i++ {
+		grams = append(grams, string(runes[:i]))
+	}
+	return grams
+}
+
+// prefix returns the shortest edge n-gram of token, the loosest prefix the
+// field was indexed under, or false if token has none.
+func (c NGramConfig) prefix(token string) (string, bool) {
+	grams := c.edge(token)
+	if len(grams) < 2 {
+		return "", false
+	}
+	return grams[1], true
+}
+
+// infixPrefix namespaces infix keys in data, apart from terms and edge
+// n-grams; like termPrefix it is a control character no term contains.
+const infixPrefix = "\x02"
+
+func infixKey(gram string) string {
+	return infixPrefix + gram
+}
+
+// infix returns every distinct substring of token within the infix sizes.
+func (c NGramConfig) infix(token string) []string {
+	if c.InfixMax == 0 {
+		return nil
+	}
+	runes := []rune(token)
+	seen := make(map[string]bool)
+	var grams []string
+	for n := c.InfixMin; n <= c.InfixMax && n <= len(runes); n++ {
+		for i := 0; i+n <= len(runes); i++ {
+			if g := string(runes[i : i+n]); !seen[g] {
+				seen[g] = true
+				grams = append(grams, g)
+			}
+		}
+	}
+	return grams
+}
+
+// infixQuery returns the grams a query token is looked up by: the token
+// itself when it fits the infix sizes, else its longest grams, all of which
+// a document term containing the token has.
+func (c NGramConfig) infixQuery(token string) []string {
+	n := utf8.RuneCountInString(token)
+	switch {
+	case c.InfixMax == 0 || n < c.InfixMin:
+		return nil
+	case n <= c.InfixMax:
+		return []string{token}
+	}
+	return NGramConfig{InfixMin: c.InfixMax, InfixMax: c.InfixMax}.infix(token)
+}
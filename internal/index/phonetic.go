@@ -0,0 +1,64 @@
+package index
+
+import (
+	"strings"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+// phoneticSep separates the encoder name from the code in phoneticData keys.
+// The tokenizer never emits control characters, so keys cannot collide with
+// terms or fragments in data.
+const phoneticSep = "\x1f"
+
+// WithPhonetic sets the phonetic encoders for a field, replacing the default
+// Soundex. No encoders turns phonetic matching off for the field.
+func WithPhonetic(field string, encoders ...analysis.PhoneticEncoder) Option {
+	return func(idx *InMemoryIndex) {
+		idx.phonetic[field] = encoders
+	}
+}
+
+func phoneticKey(encoder, code string) string {
+	return encoder + phoneticSep + code
+}
+
+func splitPhoneticKey(key string) (encoder, code string) {
+	encoder, code, _ = strings.Cut(key, phoneticSep)
+	return encoder, code
+}
+
+// phoneticKeys returns the namespaced codes of a term under every encoder
+// configured for the field.
+func (idx *InMemoryIndex) phoneticKeys(field, term string) []string {
+	var keys []string
+	for _, enc := range idx.phonetic[field] {
+		for _, code := range enc.Encode(term) {
+			keys = append(keys, phoneticKey(enc.Name(), code))
+		}
+	}
+	return keys
+}
+
+// migratePhoneticKeys moves bare Soundex codes written by older snapshots
+// into the soundex namespace. Callers hold the write lock.
+func (idx *InMemoryIndex) migratePhoneticKeys() {
+	soundex := analysis.SoundexEncoder.Name()
+
+	for key, ids := range idx.phoneticData {
+		if strings.Contains(key, phoneticSep) {
+			continue
+		}
+		delete(idx.phoneticData, key)
+		idx.phoneticData[phoneticKey(soundex, key)] = ids
+	}
+
+	for id, frags := range idx.docFragments {
+		for i, frag := range frags {
+			if _, isTerm := idx.data[frag]; !isTerm && !strings.Contains(frag, phoneticSep) {
+				frags[i] = phoneticKey(soundex, frag)
+			}
+		}
+		idx.docFragments[id] = frags
+	}
+}
@@ -0,0 +1,364 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+	"github.com/shramanb113/ZENITH/internal/core"
+)
+
+// PipelineConfig controls the background workers that turn Pending documents
+// into Converted ones by fetching their vectors.
+type PipelineConfig struct {
+	Workers     int           // concurrent embedding workers, defaults to 4
+	QueueSize   int           // buffered jobs before Add blocks, defaults to 1024
+	MaxAttempts int           // attempts before a document is reported as failed, defaults to 8
+	BaseBackoff time.Duration // delay before the first retry, doubled per attempt, defaults to 500ms
+	MaxBackoff  time.Duration // upper bound for the retry delay, defaults to 30s
+}
+
+func (c PipelineConfig) withDefaults() PipelineConfig {
+	if c.Workers <= 0 {
+		c.Workers = 4
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 1024
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 8
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = 500 * time.Millisecond
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 30 * time.Second
+	}
+	return c
+}
+
+// WithPipeline tunes the embedding worker pool.
+func WithPipeline(cfg PipelineConfig) Option {
+	return func(idx *InMemoryIndex) {
+		idx.pipelineCfg = cfg
+	}
+}
+
+var (
+	errEmptyEmbedding    = errors.New("embedder returned no vector")
+	errDimensionMismatch = errors.New("embedding dimension mismatch")
+)
+
+type embedJob struct {
+	id      uint32
+	version int64
+	text    string
+	tokens  []string
+	attempt int // tries so far, drives the backoff
+	charged int // tries that count towards MaxAttempts
+}
+
+type EmbedFailure struct {
+	ID          string
+	Attempts    int
+	Err         string
+	LastAttempt time.Time
+}
+
+type PipelineStats struct {
+	Pending   int
+	Converted int
+	Failed    int // documents whose embedding gave up; see Failures
+	Queued    int
+	Failures  []EmbedFailure
+	Cache     *analysis.CacheStats // nil unless the embedder is cached
+}
+
+type pipeline struct {
+	cfg      PipelineConfig
+	jobs     chan embedJob
+	quit     chan struct{}
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	retrying map[uint32]*time.Timer
+	failures map[uint32]EmbedFailure
+}
+
+func (idx *InMemoryIndex) startPipeline() {
+	cfg := idx.pipelineCfg.withDefaults()
+	p := &pipeline{
+		cfg:      cfg,
+		jobs:     make(chan embedJob, cfg.QueueSize),
+		quit:     make(chan struct{}),
+		retrying: make(map[uint32]*time.Timer),
+		failures: make(map[uint32]EmbedFailure),
+	}
+	idx.pipeline = p
+
+	for range cfg.Workers {
+		p.wg.Add(1)
+		go idx.embedWorker()
+	}
+}
+
+// Close stops the embedding workers. Documents still waiting for vectors stay
+// Pending and are picked up again after the next Load.
+func (idx *InMemoryIndex) Close() {
+	p := idx.pipeline
+
+	p.mu.Lock()
+	select {
+	case <-p.quit:
+	default:
+		close(p.quit)
+	}
+	for id, t := range p.retrying {
+		t.Stop()
+		delete(p.retrying, id)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (idx *InMemoryIndex) enqueueEmbedding(job embedJob) {
+	p := idx.pipeline
+
+	p.mu.Lock()
+	if t, ok := p.retrying[job.id]; ok {
+		t.Stop()
+		delete(p.retrying, job.id)
+	}
+	delete(p.failures, job.id)
+	p.mu.Unlock()
+
+	select {
+	case p.jobs <- job:
+	case <-p.quit:
+	}
+}
+
+// forgetEmbedding drops a deleted document's pending retry and failure; a
+// job already queued finds the document gone and does nothing.
+func (idx *InMemoryIndex) forgetEmbedding(id uint32) {
+	p := idx.pipeline
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t, ok := p.retrying[id]; ok {
+		t.Stop()
+		delete(p.retrying, id)
+	}
+	delete(p.failures, id)
+}
+
+func (idx *InMemoryIndex) embedWorker() {
+	p := idx.pipeline
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.quit:
+			return
+		case job := <-p.jobs:
+			idx.runEmbedJob(job)
+		}
+	}
+}
+
+func (idx *InMemoryIndex) runEmbedJob(job embedJob) {
+	if !idx.isCurrent(job) {
+		return
+	}
+
+	texts := []string{job.text}
+	idx.mu.RLock()
+	queued := make(map[string]bool)
+	for _, t := range job.tokens {
+		if _, exists := idx.wordVectors[t]; !exists && !queued[t] {
+			queued[t] = true
+			texts = append(texts, t)
+		}
+	}
+	idx.mu.RUnlock()
+
+	// One batch for the document and every token we have no vector for yet,
+	// instead of a round-trip per token.
+	embeddings := analysis.EmbedAll(context.Background(), idx.embedder, texts)
+
+	docRes := embeddings[0]
+	if docRes.Err == nil && docRes.Embedding == nil {
+		docRes.Err = errEmptyEmbedding
+	}
+	if docRes.Err == nil {
+		docRes.Err = idx.schema.Load().checkDims(docRes.Embedding)
+	}
+
+	idx.mu.Lock()
+	// Word vectors are useful even if the document itself has to be retried.
+	for i, res := range embeddings[1:] {
+		if res.Err == nil && res.Embedding != nil {
+			idx.wordVectors[texts[i+1]] = res.Embedding
+		}
+	}
+
+	doc, ok := idx.documents[job.id]
+	if !ok || doc.Version != job.version {
+		idx.mu.Unlock()
+		return
+	}
+	if docRes.Err == nil {
+		idx.vectors[job.id] = docRes.Embedding
+		doc.Status = core.TypeConverted
+	}
+	idx.mu.Unlock()
+
+	if docRes.Err != nil {
+		idx.scheduleRetry(job, doc.ID, docRes.Err)
+	}
+}
+
+func (idx *InMemoryIndex) isCurrent(job embedJob) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	doc, ok := idx.documents[job.id]
+	return ok && doc.Version == job.version && doc.Status == core.TypePending
+}
+
+func (idx *InMemoryIndex) scheduleRetry(job embedJob, originalID string, err error) {
+	p := idx.pipeline
+	job.attempt++
+	// An open circuit says nothing about this document, so it only delays it.
+	if !errors.Is(err, analysis.ErrCircuitOpen) {
+		job.charged++
+	}
+	// Retrying cannot change the size of the vector.
+	if errors.Is(err, errDimensionMismatch) {
+		job.charged = p.cfg.MaxAttempts
+	}
+
+	exhausted := job.charged >= p.cfg.MaxAttempts
+	if exhausted {
+		idx.markFailed(job)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-p.quit:
+		return
+	default:
+	}
+
+	if exhausted {
+		p.failures[job.id] = EmbedFailure{
+			ID:          originalID,
+			Attempts:    job.attempt,
+			Err:         err.Error(),
+			LastAttempt: time.Now(),
+		}
+		return
+	}
+
+	backoff := p.cfg.MaxBackoff
+	if job.attempt <= 30 {
+		backoff = p.cfg.BaseBackoff << (job.attempt - 1)
+	}
+	if backoff <= 0 || backoff > p.cfg.MaxBackoff {
+		backoff = p.cfg.MaxBackoff
+	}
+
+	p.retrying[job.id] = time.AfterFunc(backoff, func() {
+		p.mu.Lock()
+		delete(p.retrying, job.id)
+		p.mu.Unlock()
+
+		select {
+		case p.jobs <- job:
+		case <-p.quit:
+		}
+	})
+}
+
+// markFailed takes a document whose retries ran out off the pending count.
+func (idx *InMemoryIndex) markFailed(job embedJob) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if doc, ok := idx.documents[job.id]; ok && doc.Version == job.version {
+		doc.Status = core.TypeFailed
+	}
+}
+
+// PipelineStats reports how many documents still wait for vectors and which
+// ones exhausted their retries.
+func (idx *InMemoryIndex) PipelineStats() PipelineStats {
+	var stats PipelineStats
+
+	idx.mu.RLock()
+	for _, doc := range idx.documents {
+		switch doc.Status {
+		case core.TypePending:
+			stats.Pending++
+		case core.TypeConverted:
+			stats.Converted++
+		case core.TypeFailed:
+			stats.Failed++
+		}
+	}
+	idx.mu.RUnlock()
+
+	p := idx.pipeline
+	p.mu.Lock()
+	stats.Queued = len(p.jobs) + len(p.retrying)
+	for _, f := range p.failures {
+		stats.Failures = append(stats.Failures, f)
+	}
+	p.mu.Unlock()
+
+	sort.Slice(stats.Failures, func(i, j int) bool {
+		return stats.Failures[i].ID < stats.Failures[j].ID
+	})
+
+	if cached, ok := idx.embedder.(*analysis.CachedEmbedder); ok {
+		cacheStats := cached.Stats()
+		stats.Cache = &cacheStats
+	}
+
+	return stats
+}
+
+// requeuePending schedules every Pending document again, used after Load.
+// Failed ones get a fresh set of retries, as the failures are not saved.
+func (idx *InMemoryIndex) requeuePending() {
+	idx.mu.Lock()
+	var jobs []embedJob
+	for id, doc := range idx.documents {
+		if doc.Status == core.TypeFailed {
+			doc.Status = core.TypePending
+		}
+		if doc.Status == core.TypePending && doc.Fields[DefaultField] != "" {
+			var tokens []string
+			for _, t := range idx.positions[id] {
+				tokens = append(tokens, t.Term)
+			}
+			jobs = append(jobs, embedJob{
+				id:      id,
+				version: doc.Version,
+				text:    doc.Fields[DefaultField],
+				tokens:  tokens,
+			})
+		}
+	}
+	idx.mu.Unlock()
+
+	go func() {
+		for _, job := range jobs {
+			idx.enqueueEmbedding(job)
+		}
+	}()
+}
@@ -0,0 +1,249 @@
+package index
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+// maxClausePaths bounds the alternatives enumerated for one clause, since
+// stacked synonyms multiply.
+const maxClausePaths = 64
+
+// queryClause is one stretch of the query's token graph. Any of its paths
+// satisfies it: the words as typed, their compound, or a synonym phrase.
+type queryClause struct {
+	paths   [][]string
+	synonym bool // some path came from a synonym rule
+}
+
+// queryClauses cuts the analyzed query into clauses at the positions no
+// token spans across.
+func queryClauses(tokens []analysis.Token) []queryClause {
+	sorted := make([]analysis.Token, len(tokens))
+	copy(sorted, tokens)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+
+	var clauses []queryClause
+	for i := 0; i < len(sorted); {
+		start, end := sorted[i].Position, sorted[i].Position+sorted[i].Span()
+		j := i
+		for j < len(sorted) && sorted[j].Position < end {
+			end = max(end, sorted[j].Position+sorted[j].Span())
+			j++
+		}
+		clauses = append(clauses, buildClause(sorted[i:j], start, end))
+		i = j
+	}
+	return clauses
+}
+
+func buildClause(tokens []analysis.Token, start, end int) queryClause {
+	var c queryClause
+	byStart := make(map[int][]analysis.Token)
+	for _, t := range tokens {
+		byStart[t.Position] = append(byStart[t.Position], t)
+		if t.Synonym {
+			c.synonym = true
+		}
+	}
+
+	var walk func(pos int, path []string)
+	walk = func(pos int, path []string) {
+		if len(c.paths) == maxClausePaths {
+			return
+		}
+		// Positions left empty by stop words are stepped over.
+		for pos < end && len(byStart[pos]) == 0 {
+			pos++
+		}
+		if pos >= end {
+			c.paths = append(c.paths, append([]string(nil), path...))
+			return
+		}
+		for _, t := range byStart[pos] {
+			walk(pos+t.Span(), append(path, t.Term))
+		}
+	}
+	walk(start, nil)
+
+	return c
+}
+
+func (c queryClause) satisfied(matched map[string]bool) bool {
+	for _, path := range c.paths {
+		all := true
+		for _, term := range path {
+			if !matched[term] {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+func allClausesMatched(clauses []queryClause, matched map[string]bool) bool {
+	for _, c := range clauses {
+		if !c.satisfied(matched) {
+			return false
+		}
+	}
+	return true
+}
+
+// clauseTerms lists every distinct term of the query graph, in order.
+func clauseTerms(clauses []queryClause) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, c := range clauses {
+		for _, path := range c.paths {
+			for _, term := range path {
+				if !seen[term] {
+					seen[term] = true
+					terms = append(terms, term)
+				}
+			}
+		}
+	}
+	return terms
+}
+
+// SynonymPrecedence decides how explicit synonyms and the neural expansion
+// pass combine.
+type SynonymPrecedence string
+
+const (
+	// SynonymsFirst skips neural expansion for words a synonym rule covers:
+	// the dictionary is the curated answer for them.
+	SynonymsFirst SynonymPrecedence = "synonyms_first"
+	// SynonymsAndNeural expands every word both ways.
+	SynonymsAndNeural SynonymPrecedence = "both"
+)
+
+func WithSynonymPrecedence(p SynonymPrecedence) Option {
+	return func(idx *InMemoryIndex) {
+		idx.synonymPrecedence = p
+	}
+}
+
+// neuralCandidates are the query terms the neural pass may expand.
+func (idx *InMemoryIndex) neuralCandidates(clauses []queryClause) []string {
+	if idx.synonymPrecedence == SynonymsAndNeural {
+		return clauseTerms(clauses)
+	}
+
+	var plain []queryClause
+	for _, c := range clauses {
+		if !c.synonym {
+			plain = append(plain, c)
+		}
+	}
+	return clauseTerms(plain)
+}
+
+// fieldClause is a field:value or field:"several words" query clause,
+// matched against that field alone. Every term of the value must match.
+type fieldClause struct {
+	raw        string
+	field      string
+	terms      []string
+	start, end int // byte offsets of raw in the query
+}
+
+var fieldClausePattern = regexp.MustCompile(`(?:^|\s)([^\s:"/]+):("[^"]*"|[^\s"]+)`)
+
+// parseFieldClauses pulls the clauses naming an indexed field of the schema
+// out of the query and returns the rest; any other name:value stays text.
+func (idx *InMemoryIndex) parseFieldClauses(query, lang string) (string, []fieldClause) {
+	st := idx.schema.Load()
+	if st == nil {
+		return query, nil
+	}
+
+	var clauses []fieldClause
+	var rest strings.Builder
+	last := 0
+	for _, m := range fieldClausePattern.FindAllStringSubmatchIndex(query, -1) {
+		name, value := query[m[2]:m[3]], strings.Trim(query[m[4]:m[5]], `"`)
+		f, ok := st.schema.field(name)
+		if !ok || !f.Indexed || name == DefaultField || f.Type == FieldVector {
+			continue
+		}
+		clauses = append(clauses, fieldClause{
+			raw:   query[m[2]:m[5]],
+			field: name,
+			terms: idx.valueTerms(name, f, value, lang, true),
+			start: m[2],
+			end:   m[5],
+		})
+		rest.WriteString(query[last:m[0]])
+		rest.WriteByte(' ')
+		last = m[1]
+	}
+	rest.WriteString(query[last:])
+	return rest.String(), clauses
+}
+
+// fieldMatches returns the documents matching every term of the clause in
+// its field: as a term or, for text, as the prefix or infix the field is
+// indexed with. The caller holds the read lock.
+func (idx *InMemoryIndex) fieldMatches(c fieldClause) map[uint32]float64 {
+	if len(c.terms) == 0 {
+		return nil
+	}
+	ngrams := idx.fieldNGrams(c.field)
+
+	var scores map[uint32]float64
+	for _, term := range c.terms {
+		hit := make(map[uint32]bool)
+		for _, id := range idx.data[fieldKey(c.field, term)] {
+			hit[id] = true
+		}
+		if grams := ngrams.infixQuery(term); len(grams) > 0 {
+			counts := make(map[uint32]int)
+			for _, gram := range grams {
+				for _, id := range idx.data[fieldKey(c.field, infixKey(gram))] {
+					counts[id]++
+				}
+			}
+			for id, n := range counts {
+				if n == len(grams) {
+					hit[id] = true
+				}
+			}
+		}
+
+		if scores == nil {
+			scores = make(map[uint32]float64, len(hit))
+			for id := range hit {
+				scores[id] = 100.0
+			}
+			continue
+		}
+		for id := range scores {
+			if hit[id] {
+				scores[id] += 100.0
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores
+}
+
+func allFieldsMatched(clauses []fieldClause, matched map[string]bool) bool {
+	for _, c := range clauses {
+		if !matched[c.raw] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,266 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxReindexErrors caps the document errors a job keeps for its status.
+const maxReindexErrors = 10
+
+// reindexJobTTL is how long a finished job's status stays available.
+const reindexJobTTL = time.Hour
+
+var (
+	ErrJobNotFound     = errors.New("reindex job not found")
+	ErrReindexIntoSelf = errors.New("cannot reindex an index into itself")
+)
+
+type ReindexState string
+
+const (
+	ReindexRunning  ReindexState = "running"
+	ReindexDone     ReindexState = "done"
+	ReindexCanceled ReindexState = "canceled" // source or dest was deleted
+)
+
+// ReindexStatus is the progress of a reindex job. The copy is done when
+// State is done, and Copied, Failed and Skipped then add up to Total; the
+// destination may still be embedding what it got, which PendingEmbeddings
+// tells, and an alias should only be swapped once that is zero.
+type ReindexStatus struct {
+	ID                string
+	Source, Dest      string
+	State             ReindexState
+	Total             int // documents in the source when the job started
+	Copied            int
+	Failed            int
+	Skipped           int      // deleted from the source before they were copied
+	Errors            []string // the first few failures
+	PendingEmbeddings int
+	Started, Finished time.Time
+}
+
+type ReindexJob struct {
+	mu      sync.Mutex
+	status  ReindexStatus
+	sources []*InMemoryIndex
+	dest    *InMemoryIndex
+	cancel  context.CancelFunc
+	done    chan struct{} // closed when the copy stops
+}
+
+// uses reports whether the job reads from or writes to idx.
+func (j *ReindexJob) uses(idx *InMemoryIndex) bool {
+	return j.dest == idx || slices.Contains(j.sources, idx)
+}
+
+func (j *ReindexJob) finish(state ReindexState) {
+	j.mu.Lock()
+	j.status.State = state
+	j.status.Finished = time.Now()
+	j.mu.Unlock()
+}
+
+func (j *ReindexJob) expired(now time.Time) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status.State != ReindexRunning && now.Sub(j.status.Finished) > reindexJobTTL
+}
+
+func (j *ReindexJob) Status() ReindexStatus {
+	j.mu.Lock()
+	status := j.status
+	status.Errors = append([]string(nil), j.status.Errors...)
+	j.mu.Unlock()
+
+	status.PendingEmbeddings = j.dest.PipelineStats().Pending
+	return status
+}
+
+func (j *ReindexJob) skip() {
+	j.mu.Lock()
+	j.status.Skipped++
+	j.mu.Unlock()
+}
+
+func (j *ReindexJob) record(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err == nil {
+		j.status.Copied++
+		return
+	}
+	j.status.Failed++
+	if len(j.status.Errors) < maxReindexErrors {
+		j.status.Errors = append(j.status.Errors, err.Error())
+	}
+}
+
+// Reindex copies the stored documents of source, an index or alias, into
+// dest in the background, analyzing and embedding them again with dest's
+// settings. Fields that were not stored cannot be copied, and writes to the
+// source after the job started may be missed. Deleting the source or dest
+// cancels the job.
+func (c *Catalog) Reindex(source, dest string) (*ReindexJob, error) {
+	from, err := c.Resolve(source)
+	if err != nil {
+		return nil, err
+	}
+	to, err := c.Get(dest)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range from {
+		if idx == to {
+			return nil, fmt.Errorf("%w: %q", ErrReindexIntoSelf, dest)
+		}
+	}
+
+	ids := make([][]string, len(from))
+	total := 0
+	for i, idx := range from {
+		ids[i] = idx.documentIDs()
+		total += len(ids[i])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c.jobsMu.Lock()
+	c.pruneJobs()
+	c.nextJob++
+	job := &ReindexJob{
+		sources: from,
+		dest:    to,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		status: ReindexStatus{
+			ID:      fmt.Sprintf("reindex-%d", c.nextJob),
+			Source:  source,
+			Dest:    dest,
+			State:   ReindexRunning,
+			Total:   total,
+			Started: time.Now(),
+		},
+	}
+	c.jobs[job.status.ID] = job
+	c.jobsMu.Unlock()
+
+	go func() {
+		defer close(job.done)
+		defer cancel()
+		for i, idx := range from {
+			for _, id := range ids[i] {
+				if ctx.Err() != nil {
+					job.finish(ReindexCanceled)
+					return
+				}
+				text, opts, ok := idx.storedDocument(id)
+				if !ok {
+					job.skip()
+					continue
+				}
+				if err := to.Add(id, text, opts...); err != nil {
+					job.record(fmt.Errorf("document %q: %w", id, err))
+					continue
+				}
+				job.record(nil)
+			}
+		}
+
+		job.finish(ReindexDone)
+	}()
+	return job, nil
+}
+
+// Job returns a reindex job that is running or finished within the last
+// hour.
+func (c *Catalog) Job(id string) (*ReindexJob, error) {
+	c.jobsMu.Lock()
+	defer c.jobsMu.Unlock()
+	c.pruneJobs()
+	job, ok := c.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrJobNotFound, id)
+	}
+	return job, nil
+}
+
+// cancelJobs stops the jobs reading from or writing to idx.
+func (c *Catalog) cancelJobs(idx *InMemoryIndex) {
+	c.jobsMu.Lock()
+	defer c.jobsMu.Unlock()
+	for _, job := range c.jobs {
+		if job.uses(idx) {
+			job.cancel()
+		}
+	}
+}
+
+// stopJobs cancels every job and waits for them to stop writing.
+func (c *Catalog) stopJobs() {
+	c.jobsMu.Lock()
+	jobs := slices.Collect(maps.Values(c.jobs))
+	c.jobsMu.Unlock()
+
+	for _, job := range jobs {
+		job.cancel()
+	}
+	for _, job := range jobs {
+		<-job.done
+	}
+}
+
+// pruneJobs forgets jobs finished longer than reindexJobTTL ago. Callers
+// hold jobsMu.
+func (c *Catalog) pruneJobs() {
+	now := time.Now()
+	maps.DeleteFunc(c.jobs, func(_ string, job *ReindexJob) bool {
+		return job.expired(now)
+	})
+}
+
+func (idx *InMemoryIndex) documentIDs() []string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	ids := make([]string, 0, len(idx.documents))
+	for _, doc := range idx.documents {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+// storedDocument returns what Add needs to index the document again.
+func (idx *InMemoryIndex) storedDocument(id string) (string, []AddOption, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	doc, ok := idx.documents[internalIDFor(id)]
+	if !ok {
+		return "", nil, false
+	}
+
+	fields := maps.Clone(doc.Fields)
+	text, title := fields[DefaultField], fields[TitleField]
+	delete(fields, DefaultField)
+	delete(fields, TitleField)
+	opts := []AddOption{WithTitle(title), WithFields(fields)}
+
+	if _, ok := doc.Metadata[PopularityKey]; ok {
+		opts = append(opts, WithPopularity(popularityOf(doc)))
+	}
+	// A language that detection did not pick was given by the caller.
+	if lang := languageOf(doc); lang != "" {
+		detected, _ := doc.Metadata[FieldLanguagesKey].(map[string]string)
+		if detected[DefaultField] != lang {
+			opts = append(opts, WithLanguage(lang))
+		}
+	}
+	return text, opts, true
+}
@@ -0,0 +1,467 @@
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+	"github.com/shramanb113/ZENITH/internal/core"
+)
+
+type FieldType string
+
+const (
+	FieldText    FieldType = "text"
+	FieldKeyword FieldType = "keyword"
+	FieldNumber  FieldType = "number"
+	FieldVector  FieldType = "vector"
+)
+
+type VectorMetric string
+
+const (
+	MetricCosine VectorMetric = "cosine"
+	MetricDot    VectorMetric = "dot_product"
+	MetricL2     VectorMetric = "l2"
+)
+
+var (
+	ErrInvalidSchema      = errors.New("invalid schema")
+	ErrIncompatibleSchema = errors.New("incompatible schema change")
+	ErrIndexExists        = errors.New("index already exists")
+	ErrNoSchema           = errors.New("index has no schema")
+	ErrInvalidDocument    = errors.New("document does not match the schema")
+)
+
+// FieldSchema describes one field. Text fields are analyzed, keyword and
+// number fields are indexed as a single term, and the vector field holds the
+// embedding of the data field. Indexed fields are searchable; stored ones
+// are kept on the document record. NGrams sets the edge and infix n-grams
+// of a text field, replacing those of the index configuration.
+type FieldSchema struct {
+	Type           FieldType    `json:"type"`
+	Analyzer       string       `json:"analyzer,omitempty"`
+	SearchAnalyzer string       `json:"search_analyzer,omitempty"`
+	Stored         bool         `json:"stored"`
+	Indexed        bool         `json:"indexed"`
+	Dims           int          `json:"dims,omitempty"`
+	Metric         VectorMetric `json:"metric,omitempty"`
+	NGrams         *NGramConfig `json:"ngrams,omitempty"`
+}
+
+// Schema is the set of fields an index accepts. Version counts the mapping
+// changes applied since the index was created.
+type Schema struct {
+	Fields  map[string]FieldSchema `json:"fields"`
+	Version int64                  `json:"version,omitempty"`
+}
+
+// implicitFields are what IndexDocuments always writes; a schema may
+// declare them, but only as they are.
+var implicitFields = map[string]FieldSchema{
+	DefaultField: {Type: FieldText, Stored: true, Indexed: true},
+	TitleField:   {Type: FieldText, Stored: true},
+}
+
+func LoadSchema(path string) (Schema, error) {
+	var s Schema
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return s, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (f FieldSchema) validate(name string) error {
+	switch f.Type {
+	case FieldText:
+	case FieldKeyword, FieldNumber:
+		if f.Analyzer != "" || f.SearchAnalyzer != "" {
+			return fmt.Errorf("%w: %s field %q takes no analyzer", ErrInvalidSchema, f.Type, name)
+		}
+	case FieldVector:
+		if f.Dims <= 0 {
+			return fmt.Errorf("%w: vector field %q needs dims", ErrInvalidSchema, name)
+		}
+		switch f.Metric {
+		case MetricCosine, MetricDot, MetricL2:
+		default:
+			return fmt.Errorf("%w: vector field %q has unknown metric %q", ErrInvalidSchema, name, f.Metric)
+		}
+	default:
+		return fmt.Errorf("%w: field %q has unknown type %q", ErrInvalidSchema, name, f.Type)
+	}
+	if f.Type != FieldVector && (f.Dims != 0 || f.Metric != "") {
+		return fmt.Errorf("%w: only vector fields take dims and metric, %q is %s", ErrInvalidSchema, name, f.Type)
+	}
+	if f.NGrams != nil {
+		if f.Type != FieldText {
+			return fmt.Errorf("%w: only text fields take n-grams, %q is %s", ErrInvalidSchema, name, f.Type)
+		}
+		if err := f.NGrams.validate(); err != nil {
+			return fmt.Errorf("%w: field %q: %w", ErrInvalidSchema, name, err)
+		}
+	}
+
+	if implicit, ok := implicitFields[name]; ok {
+		if f.Type != implicit.Type || f.Stored != implicit.Stored || f.Indexed != implicit.Indexed {
+			return fmt.Errorf("%w: field %q must be a %s field with stored=%v and indexed=%v", ErrInvalidSchema, name, implicit.Type, implicit.Stored, implicit.Indexed)
+		}
+	}
+	return nil
+}
+
+// Validate checks every field and that at most one vector field is
+// declared, since a document has one embedding.
+func (s Schema) Validate() error {
+	var vectors []string
+	for name, f := range s.Fields {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: empty field name", ErrInvalidSchema)
+		}
+		// Queries name fields as name:value.
+		if strings.ContainsAny(name, ":\"/") || strings.ContainsFunc(name, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) {
+			return fmt.Errorf("%w: field name %q may not contain spaces, control characters or any of : \" /", ErrInvalidSchema, name)
+		}
+		if err := f.validate(name); err != nil {
+			return err
+		}
+		if f.Type == FieldVector {
+			vectors = append(vectors, name)
+		}
+	}
+	if len(vectors) > 1 {
+		sort.Strings(vectors)
+		return fmt.Errorf("%w: more than one vector field: %s", ErrInvalidSchema, strings.Join(vectors, ", "))
+	}
+	return nil
+}
+
+// Evolve applies a mapping change: new fields are added, and declared ones
+// may only change what needs no reindexing, their search analyzer and
+// whether new documents store them. Fields left out are kept.
+func (s Schema) Evolve(fields map[string]FieldSchema) (Schema, error) {
+	next := Schema{Fields: maps.Clone(s.Fields), Version: s.Version + 1}
+	if next.Fields == nil {
+		next.Fields = make(map[string]FieldSchema)
+	}
+	for name, f := range fields {
+		if err := f.validate(name); err != nil {
+			return s, err
+		}
+		old, ok := s.Fields[name]
+		if ok {
+			// The indexed n-grams would no longer match the queried ones.
+			if !sameNGrams(old.NGrams, f.NGrams) {
+				return s, fmt.Errorf("%w: field %q cannot change its n-grams without a reindex", ErrIncompatibleSchema, name)
+			}
+			old.SearchAnalyzer, old.Stored, old.NGrams = f.SearchAnalyzer, f.Stored, f.NGrams
+			if old != f {
+				return s, fmt.Errorf("%w: field %q can only change search_analyzer and stored", ErrIncompatibleSchema, name)
+			}
+		}
+		next.Fields[name] = f
+	}
+	if err := next.Validate(); err != nil {
+		return s, err
+	}
+	return next, nil
+}
+
+func sameNGrams(a, b *NGramConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (s Schema) field(name string) (FieldSchema, bool) {
+	if f, ok := s.Fields[name]; ok {
+		return f, true
+	}
+	f, ok := implicitFields[name]
+	return f, ok
+}
+
+func (s Schema) vectorField() (string, FieldSchema, bool) {
+	for name, f := range s.Fields {
+		if f.Type == FieldVector {
+			return name, f, true
+		}
+	}
+	return "", FieldSchema{}, false
+}
+
+// schemaState is a schema with its analyzers resolved; it is swapped as a
+// whole so searches never see half a mapping change.
+type schemaState struct {
+	schema    Schema
+	analyzers map[string]fieldAnalyzers
+}
+
+// WithSchema creates the index with a schema. Unknown analyzers make
+// NewInMemoryIndex log and fall back to the field's default analyzer; use
+// CreateSchema to have them reported.
+func WithSchema(s Schema) Option {
+	return func(idx *InMemoryIndex) {
+		idx.pendingSchema = &s
+	}
+}
+
+// WithFields sets further document fields, leaving the data and title
+// fields alone.
+func WithFields(fields map[string]string) AddOption {
+	return func(doc *core.Document) {
+		for name, value := range fields {
+			if _, ok := doc.Fields[name]; !ok && name != TitleField {
+				doc.Fields[name] = value
+			}
+		}
+	}
+}
+
+// Schema returns the index's schema, and false if it has none and accepts
+// any field.
+func (idx *InMemoryIndex) Schema() (Schema, bool) {
+	st := idx.schema.Load()
+	if st == nil {
+		return Schema{}, false
+	}
+	return st.schema, true
+}
+
+// CreateSchema gives a new, empty index its schema, at version 1.
+func (idx *InMemoryIndex) CreateSchema(s Schema) error {
+	s.Version = 1
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	st, err := idx.resolveSchema(s)
+	if err != nil {
+		return err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.schema.Load() != nil || len(idx.documents) > 0 {
+		return ErrIndexExists
+	}
+	idx.schema.Store(st)
+	return nil
+}
+
+// PutMapping evolves the schema with fields; see Schema.Evolve. Documents
+// already indexed are left as they are.
+func (idx *InMemoryIndex) PutMapping(fields map[string]FieldSchema) (Schema, error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	st := idx.schema.Load()
+	if st == nil {
+		return Schema{}, ErrNoSchema
+	}
+	current := st.schema
+	next, err := current.Evolve(fields)
+	if err != nil {
+		return current, err
+	}
+	if st, err = idx.resolveSchema(next); err != nil {
+		return current, err
+	}
+	idx.schema.Store(st)
+	return next, nil
+}
+
+func (idx *InMemoryIndex) resolveSchema(s Schema) (*schemaState, error) {
+	st := &schemaState{schema: s, analyzers: make(map[string]fieldAnalyzers)}
+	for name, f := range s.Fields {
+		if f.Type != FieldText || (f.Analyzer == "" && f.SearchAnalyzer == "") {
+			continue
+		}
+		fa := idx.configAnalyzers(name)
+		if f.Analyzer != "" {
+			a, err := idx.analyzerByName(f.Analyzer)
+			if err != nil {
+				return nil, fmt.Errorf("%w: field %q: %w", ErrInvalidSchema, name, err)
+			}
+			fa.index, fa.search = a, a
+		}
+		if f.SearchAnalyzer != "" {
+			a, err := idx.analyzerByName(f.SearchAnalyzer)
+			if err != nil {
+				return nil, fmt.Errorf("%w: field %q: %w", ErrInvalidSchema, name, err)
+			}
+			fa.search = a
+		}
+		st.analyzers[name] = fa
+	}
+	return st, nil
+}
+
+// installSchema sets a schema read from a snapshot or option, which has to
+// be taken as it is: a field whose analyzer is gone keeps its default ones.
+func (idx *InMemoryIndex) installSchema(s Schema) {
+	st := &schemaState{schema: s, analyzers: make(map[string]fieldAnalyzers)}
+	for name, f := range s.Fields {
+		one, err := idx.resolveSchema(Schema{Fields: map[string]FieldSchema{name: f}})
+		if err != nil {
+			log.Printf("⚠️ %v; the field keeps its default analyzers", err)
+			continue
+		}
+		maps.Copy(st.analyzers, one.analyzers)
+	}
+	idx.schema.Store(st)
+}
+
+// validateDocument checks doc's fields against the schema, if there is
+// one.
+func (idx *InMemoryIndex) validateDocument(doc *core.Document) error {
+	st := idx.schema.Load()
+	if st == nil {
+		return nil
+	}
+	for name, value := range doc.Fields {
+		f, ok := st.schema.field(name)
+		if !ok {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidDocument, name)
+		}
+		switch f.Type {
+		case FieldVector:
+			return fmt.Errorf("%w: field %q is computed from %q", ErrInvalidDocument, name, DefaultField)
+		case FieldNumber:
+			if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+				return fmt.Errorf("%w: field %q is not a number: %q", ErrInvalidDocument, name, value)
+			}
+		}
+	}
+	return nil
+}
+
+// fieldSep opens and closes the field name in the keys a field's terms are
+// indexed under, so every field besides the data field has a posting space
+// of its own. No term, phonetic key or field name starts with it.
+const fieldSep = "\x1e"
+
+func fieldKey(field, key string) string {
+	if field == DefaultField {
+		return key
+	}
+	return fieldSep + field + fieldSep + key
+}
+
+// fieldTerms returns the terms of the indexed fields other than the data
+// field, by field, and drops the fields that are not stored.
+func (idx *InMemoryIndex) fieldTerms(doc *core.Document) map[string][]string {
+	st := idx.schema.Load()
+	if st == nil {
+		return nil
+	}
+	terms := make(map[string][]string)
+	for name, value := range doc.Fields {
+		f, _ := st.schema.field(name)
+		if name != DefaultField && f.Indexed {
+			if t := idx.valueTerms(name, f, value, fieldLanguageOf(doc, name), false); len(t) > 0 {
+				terms[name] = t
+			}
+		}
+		if !f.Stored {
+			delete(doc.Fields, name)
+		}
+	}
+	return terms
+}
+
+// valueTerms are the terms a value of field is indexed under or, with
+// search set, looked up by. Text is analyzed; a keyword or number is one
+// term.
+func (idx *InMemoryIndex) valueTerms(name string, f FieldSchema, value, lang string, search bool) []string {
+	switch f.Type {
+	case FieldText:
+		a := idx.analyzersFor(name, lang)
+		if search {
+			return a.search.Tokenize(value)
+		}
+		return a.index.Tokenize(value)
+	case FieldKeyword:
+		if term := strings.ToLower(strings.TrimSpace(value)); term != "" {
+			return []string{term}
+		}
+	case FieldNumber:
+		if n, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return []string{strconv.FormatFloat(n, 'f', -1, 64)}
+		}
+	}
+	return nil
+}
+
+// fieldNGrams is the n-gram configuration field is indexed with; keyword
+// and number fields only match whole values.
+func (idx *InMemoryIndex) fieldNGrams(field string) NGramConfig {
+	if st := idx.schema.Load(); st != nil && field != DefaultField {
+		if f, ok := st.schema.field(field); ok && f.Type != FieldText {
+			return NGramConfig{}
+		}
+	}
+	return idx.ngramsFor(field)
+}
+
+// metric is the schema's vector metric, cosine without one.
+func (st *schemaState) metric() VectorMetric {
+	if st != nil {
+		if _, f, ok := st.schema.vectorField(); ok && f.Metric != "" {
+			return f.Metric
+		}
+	}
+	return MetricCosine
+}
+
+// describe says what similarity scores under the metric, for explanations.
+func (m VectorMetric) describe() string {
+	switch m {
+	case MetricDot:
+		return "dot product of query and document vectors"
+	case MetricL2:
+		return "1 / (1 + euclidean distance) of query and document vectors"
+	}
+	return "cosine similarity of query and document vectors"
+}
+
+// similarity scores a document vector against the query vector with the
+// schema's metric; higher is closer.
+func (st *schemaState) similarity(query, doc []float32) float64 {
+	switch st.metric() {
+	case MetricDot:
+		return float64(analysis.DotProduct(query, doc))
+	case MetricL2:
+		return 1 / (1 + float64(analysis.EuclideanDistance(query, doc)))
+	}
+	return float64(analysis.CosineSimilarity(query, doc))
+}
+
+// checkDims rejects an embedding the schema's vector field cannot hold.
+func (st *schemaState) checkDims(vec []float32) error {
+	if st == nil {
+		return nil
+	}
+	if name, f, ok := st.schema.vectorField(); ok && len(vec) != f.Dims {
+		return fmt.Errorf("%w: field %q holds %d dims, the embedder returned %d", errDimensionMismatch, name, f.Dims, len(vec))
+	}
+	return nil
+}
+
+// schemaSnapshot is what Save writes: the schema, or an empty one.
+func (idx *InMemoryIndex) schemaSnapshot() Schema {
+	s, _ := idx.Schema()
+	return s
+}
@@ -0,0 +1,79 @@
+package index
+
+import (
+	"sort"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+// SearchOptions carries per-request settings. Zero values fall back to the
+// index defaults.
+type SearchOptions struct {
+	Fusion    *FusionConfig
+	Explain   bool             // attach an Explanation to every hit
+	Highlight *HighlightConfig // attach highlighted fragments to every hit
+	Fuzziness *analysis.Fuzziness
+	Language  string // analyze the query with this language's analyzer
+
+	unlimited bool // keep every hit instead of the top maxHits
+}
+
+// maxHits is how many hits a search returns.
+const maxHits = 5
+
+type SearchResults struct {
+	Hits            []SearchResponse
+	KeywordHits     int    // documents the query's own terms matched, before neural expansion and truncation
+	SemanticSkipped bool   // the vector leg or neural expansion did not run (fully)
+	SkipReason      string // why, e.g. "embedder circuit open" or a deadline
+	Language        string // the query's language, given or detected; empty if unknown
+
+	// ExpansionTruncated is set when a wildcard or regex clause hit the
+	// expansion limit or timeout and matched only part of its terms.
+	ExpansionTruncated bool
+}
+
+func (r *SearchResults) skipSemantic(err error) {
+	r.SemanticSkipped = true
+	if r.SkipReason == "" {
+		r.SkipReason = err.Error()
+	}
+}
+
+// MergeResults combines the results of one query over several indexes,
+// such as those behind an alias, keeping the best hits. A document found in
+// more than one, as while an alias spans the old and new index of a
+// reindex, is kept once with its best score.
+func MergeResults(results ...SearchResults) SearchResults {
+	var merged SearchResults
+	for _, r := range results {
+		merged.Hits = append(merged.Hits, r.Hits...)
+		merged.KeywordHits += r.KeywordHits
+		if r.SemanticSkipped {
+			merged.SemanticSkipped = true
+			if merged.SkipReason == "" {
+				merged.SkipReason = r.SkipReason
+			}
+		}
+		if merged.Language == "" {
+			merged.Language = r.Language
+		}
+		merged.ExpansionTruncated = merged.ExpansionTruncated || r.ExpansionTruncated
+	}
+	sort.SliceStable(merged.Hits, func(i, j int) bool {
+		return merged.Hits[i].Score > merged.Hits[j].Score
+	})
+	seen := make(map[string]bool, len(merged.Hits))
+	hits := merged.Hits[:0]
+	for _, hit := range merged.Hits {
+		if !seen[hit.ID] {
+			seen[hit.ID] = true
+			hits = append(hits, hit)
+		}
+	}
+	merged.Hits = hits
+	if len(merged.Hits) > maxHits {
+		merged.Hits = merged.Hits[:maxHits]
+	}
+	return merged
+}
@@ -0,0 +1,145 @@
+package index
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+)
+
+// Noisy channel parameters: how likely a word is typed as intended, and how
+// likely each single edit is.
+const (
+	spellKeepProb = 0.95
+	spellEditProb = 0.01
+)
+
+type termCorrection struct {
+	token analysis.Token
+	term  string
+}
+
+// CorrectQuery proposes a corrected spelling of the whole query. Each analyzed
+// token is replaced by the indexed term c maximizing P(c) * P(token | c),
+// where P(c) comes from tokenCounts and P(token | c) decays with the edit
+// distance. Words the index knows are kept unless a neighbor is far more
+// common. It reports false if nothing would change.
+func (idx *InMemoryIndex) CorrectQuery(query string) (string, bool) {
+	analyzed := idx.searchAnalyzer(DefaultField).Analyze(query)
+	_, fields := idx.parseFieldClauses(query, "")
+	inField := func(t analysis.Token) bool {
+		for _, c := range fields {
+			if t.Start >= c.start && t.Start < c.end {
+				return true
+			}
+		}
+		return false
+	}
+
+	idx.mu.RLock()
+	var corrections []termCorrection
+	for _, t := range analyzed {
+		if t.Keyword || t.Synonym || inField(t) {
+			// URLs and compounds are corrected through their parts,
+			// synonyms were never typed, and field values are not words
+			// of the data field.
+			continue
+		}
+		if best := idx.bestCorrection(t.Term); best != t.Term {
+			corrections = append(corrections, termCorrection{token: t, term: best})
+		}
+	}
+	idx.mu.RUnlock()
+
+	if len(corrections) == 0 {
+		return query, false
+	}
+
+	writtenAs := idx.suggestSnapshot().writtenAs
+
+	var b strings.Builder
+	cursor := 0
+	for _, c := range corrections {
+		if c.token.Start < cursor {
+			// Another token at the same spot was already corrected.
+			continue
+		}
+		word, ok := writtenAs[c.term]
+		if !ok {
+			word = c.term
+		}
+		b.WriteString(query[cursor:c.token.Start])
+		b.WriteString(matchCase(query[c.token.Start:c.token.End], word))
+		cursor = c.token.End
+	}
+	b.WriteString(query[cursor:])
+
+	return b.String(), true
+}
+
+// bestCorrection returns the most probable intended term for one query term.
+// Callers hold the read lock.
+func (idx *InMemoryIndex) bestCorrection(term string) string {
+	runes := utf8.RuneCountInString(term)
+	budget := idx.fuzziness.MaxEdits(runes)
+	if budget == 0 || isNumeric(term) {
+		return term
+	}
+
+	vocabSize := float64(len(idx.tokenCounts))
+	logPrior := func(c string) float64 {
+		return math.Log(float64(idx.tokenCounts[c]+1) / (float64(idx.tokenTotal) + vocabSize))
+	}
+
+	best, bestScore := term, math.Inf(-1)
+	if idx.globalSeen[term] {
+		bestScore = logPrior(term) + math.Log(spellKeepProb)
+	}
+
+	spread := lengthSpread(budget, idx.editCosts)
+	for size := runes - spread; size <= runes+spread; size++ {
+		for _, candidate := range idx.vocabulary[size] {
+			if candidate == term {
+				continue
+			}
+			dist, ok := analysis.DamerauLevenshtein(term, candidate, idx.editCosts, budget)
+			if !ok || dist == 0 {
+				continue
+			}
+			score := logPrior(candidate) + dist*math.Log(spellEditProb)
+			if score > bestScore || (score == bestScore && candidate < best) {
+				best, bestScore = candidate, score
+			}
+		}
+	}
+
+	return best
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCase spells replacement the way original was capitalized.
+func matchCase(original, replacement string) string {
+	switch {
+	case original == strings.ToUpper(original) && utf8.RuneCountInString(original) > 1:
+		return strings.ToUpper(replacement)
+	case startsUpper(original):
+		r, size := utf8.DecodeRuneInString(replacement)
+		return string(unicode.ToUpper(r)) + replacement[size:]
+	}
+	return replacement
+}
+
+func startsUpper(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r)
+}
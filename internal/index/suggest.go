@@ -0,0 +1,480 @@
+package index
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/shramanb113/ZENITH/internal/analysis"
+	"github.com/shramanb113/ZENITH/internal/core"
+)
+
+const (
+	// TitleField and PopularityKey hold what title completions are built from.
+	TitleField    = "title"
+	PopularityKey = "popularity"
+)
+
+type SuggestSource string
+
+const (
+	SuggestTerms  SuggestSource = "terms"
+	SuggestTitles SuggestSource = "titles"
+)
+
+type SuggestOptions struct {
+	Source SuggestSource // defaults to SuggestTerms
+	Size   int           // defaults to 5
+	Fuzzy  bool          // also complete prefixes within 1 edit (2 from 6 runes on)
+}
+
+type Suggestion struct {
+	Text     string
+	ID       string  // the document, for title completions
+	Weight   float64 // occurrences for terms, popularity for titles
+	Distance int     // edits between the typed prefix and the completion's prefix
+}
+
+// AddOption sets optional document attributes on Add.
+type AddOption func(*core.Document)
+
+// WithTitle stores a title used for title completions.
+func WithTitle(title string) AddOption {
+	return func(doc *core.Document) {
+		if title != "" {
+			doc.Fields[TitleField] = title
+		}
+	}
+}
+
+// WithPopularity weights the document's title completion.
+func WithPopularity(popularity float64) AddOption {
+	return func(doc *core.Document) {
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]interface{})
+		}
+		doc.Metadata[PopularityKey] = popularity
+	}
+}
+
+func popularityOf(doc *core.Document) float64 {
+	p, _ := doc.Metadata[PopularityKey].(float64)
+	return p
+}
+
+// suggestDict is a sorted key list; every key sharing a prefix sits in one
+// contiguous run, so prefix lookups are two binary searches and fuzzy lookups
+// walk it like a trie.
+type suggestDict struct {
+	keys    []string
+	runes   [][]rune
+	weights []float64
+	refs    []int // index into texts
+	texts   []string
+	ids     []string
+}
+
+type suggester struct {
+	terms     *suggestDict
+	titles    *suggestDict
+	writtenAs map[string]string // indexed term -> how it is usually written
+}
+
+// surfaceTerm is what the user typed for a token, folded to lower case, so
+// completions read like words rather than stems.
+func surfaceTerm(text string, t analysis.Token) string {
+	if t.Start < 0 || t.End > len(text) || t.Start >= t.End {
+		return t.Term
+	}
+	return strings.ToLower(text[t.Start:t.End])
+}
+
+// countSurface adds delta occurrences of the document's surface terms.
+// Callers hold the write lock.
+func (idx *InMemoryIndex) countSurface(text string, tokens []analysis.Token, delta int) {
+	for _, t := range tokens {
+		surface := surfaceTerm(text, t)
+		idx.surfaceCounts[surface] += delta
+		idx.surfaceTerms[surface] = t.Term
+		if idx.surfaceCounts[surface] <= 0 {
+			delete(idx.surfaceCounts, surface)
+			delete(idx.surfaceTerms, surface)
+		}
+	}
+	idx.suggestDirty.Store(true)
+}
+
+// Suggest completes a prefix from the indexed terms or titles without running
+// a search. The first call after a write serves the current dictionaries and
+// rebuilds them in the background, so completions may briefly lag writes.
+func (idx *InMemoryIndex) Suggest(prefix string, opts SuggestOptions) []Suggestion {
+	if opts.Size <= 0 {
+		opts.Size = 5
+	}
+
+	s := idx.suggestSnapshot()
+	prefix = normalizeSuggestText(prefix)
+
+	if opts.Source == SuggestTitles {
+		return s.titles.complete(prefix, "", opts)
+	}
+
+	// Only the word being typed is completed; the words before it are kept.
+	head, last := "", prefix
+	if i := strings.LastIndexByte(prefix, ' '); i >= 0 {
+		head, last = prefix[:i+1], prefix[i+1:]
+	}
+	return s.terms.complete(last, head, opts)
+}
+
+// suggestSnapshot returns the current dictionaries, starting a rebuild if
+// the index changed since they were built. Only the very first call waits
+// for one.
+func (idx *InMemoryIndex) suggestSnapshot() *suggester {
+	s := idx.suggest.Load()
+	if s == nil {
+		idx.suggestBuild.Lock()
+		if s = idx.suggest.Load(); s == nil {
+			s = idx.rebuildSuggester()
+		}
+		idx.suggestBuild.Unlock()
+		return s
+	}
+
+	if idx.suggestDirty.Load() && idx.suggestBuild.TryLock() {
+		go func() {
+			defer idx.suggestBuild.Unlock()
+			idx.rebuildSuggester()
+		}()
+	}
+	return s
+}
+
+// rebuildSuggester builds and installs new dictionaries. Only copying what
+// they are built from holds the index lock, so writes and searches are not
+// held up by the sort. Callers hold suggestBuild.
+func (idx *InMemoryIndex) rebuildSuggester() *suggester {
+	// A write from here on marks the index dirty again.
+	idx.suggestDirty.Store(false)
+	s := idx.suggestSource().build()
+	idx.suggest.Store(s)
+	return s
+}
+
+func normalizeSuggestText(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+// suggestSource is what the dictionaries are built from, copied out of the
+// index.
+type suggestSource struct {
+	surfaceCounts map[string]int
+	surfaceTerms  map[string]string
+	titles        []suggestTitle
+}
+
+type suggestTitle struct {
+	title, id  string
+	popularity float64
+}
+
+func (idx *InMemoryIndex) suggestSource() suggestSource {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	src := suggestSource{
+		surfaceCounts: maps.Clone(idx.surfaceCounts),
+		surfaceTerms:  maps.Clone(idx.surfaceTerms),
+	}
+	for _, doc := range idx.documents {
+		if title := doc.Fields[TitleField]; title != "" {
+			src.titles = append(src.titles, suggestTitle{title: title, id: doc.ID, popularity: popularityOf(doc)})
+		}
+	}
+	return src
+}
+
+func (src suggestSource) build() *suggester {
+	terms := &suggestDict{}
+	writtenAs := make(map[string]string)
+	for surface, count := range src.surfaceCounts {
+		terms.add(surface, surface, "", float64(count))
+
+		// The most common spelling of each indexed term, ties broken
+		// alphabetically so rebuilds agree.
+		term := src.surfaceTerms[surface]
+		if cur, ok := writtenAs[term]; !ok || count > src.surfaceCounts[cur] || (count == src.surfaceCounts[cur] && surface < cur) {
+			writtenAs[term] = surface
+		}
+	}
+
+	titles := &suggestDict{}
+	for _, t := range src.titles {
+		norm := normalizeSuggestText(t.title)
+
+		// Every word start is a key, so "sys" finds "Ranking Systems".
+		for i, r := range norm {
+			if i == 0 || (norm[i-1] == ' ' && r != ' ') {
+				titles.add(norm[i:], t.title, t.id, t.popularity)
+			}
+		}
+	}
+
+	terms.sort()
+	titles.sort()
+
+	return &suggester{terms: terms, titles: titles, writtenAs: writtenAs}
+}
+
+func (d *suggestDict) add(key, text, id string, weight float64) {
+	d.keys = append(d.keys, key)
+	d.weights = append(d.weights, weight)
+	d.refs = append(d.refs, len(d.texts))
+	d.texts = append(d.texts, text)
+	d.ids = append(d.ids, id)
+}
+
+func (d *suggestDict) sort() {
+	order := make([]int, len(d.keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return d.keys[order[a]] < d.keys[order[b]]
+	})
+
+	keys := make([]string, len(order))
+	weights := make([]float64, len(order))
+	refs := make([]int, len(order))
+	d.runes = make([][]rune, len(order))
+	for i, o := range order {
+		keys[i], weights[i], refs[i] = d.keys[o], d.weights[o], d.refs[o]
+		d.runes[i] = []rune(keys[i])
+	}
+	d.keys, d.weights, d.refs = keys, weights, refs
+}
+
+// prefixRange returns the run of keys starting with prefix.
+func (d *suggestDict) prefixRange(prefix string) (int, int) {
+	lo := sort.SearchStrings(d.keys, prefix)
+	hi := lo + sort.Search(len(d.keys)-lo, func(i int) bool {
+		return !strings.HasPrefix(d.keys[lo+i], prefix)
+	})
+	return lo, hi
+}
+
+type suggestMatch struct {
+	lo, hi   int
+	distance int
+}
+
+func (d *suggestDict) complete(prefix, head string, opts SuggestOptions) []Suggestion {
+	if prefix == "" {
+		return nil
+	}
+
+	lo, hi := d.prefixRange(prefix)
+	matches := []suggestMatch{{lo: lo, hi: hi}}
+	if opts.Fuzzy {
+		matches = append(matches, d.fuzzyPrefix([]rune(prefix), lo, hi)...)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	// Matches arrive closest first, so a text's first appearance is its best
+	// one and only the current top Size need to be kept.
+	var top []Suggestion
+	var topRefs []int
+	taken := make(map[int]bool)
+	for _, m := range matches {
+		for i := m.lo; i < m.hi; i++ {
+			ref := d.refs[i]
+			if taken[ref] {
+				continue
+			}
+			s := Suggestion{
+				Text:     d.texts[ref],
+				ID:       d.ids[ref],
+				Weight:   d.weights[i],
+				Distance: m.distance,
+			}
+			if len(top) == opts.Size && !suggestionLess(s, top[len(top)-1]) {
+				continue
+			}
+			pos := sort.Search(len(top), func(k int) bool { return suggestionLess(s, top[k]) })
+			if len(top) == opts.Size {
+				delete(taken, topRefs[len(top)-1])
+				top, topRefs = top[:len(top)-1], topRefs[:len(top)-1]
+			}
+			top = slices.Insert(top, pos, s)
+			topRefs = slices.Insert(topRefs, pos, ref)
+			taken[ref] = true
+		}
+	}
+
+	for i := range top {
+		top[i].Text = head + top[i].Text
+	}
+	return top
+}
+
+// suggestionLess orders exact prefix matches first, then the most frequent
+// or popular.
+func suggestionLess(a, b Suggestion) bool {
+	if a.Distance != b.Distance {
+		return a.Distance < b.Distance
+	}
+	if a.Weight != b.Weight {
+		return a.Weight > b.Weight
+	}
+	return a.Text < b.Text
+}
+
+// maxPrefixEdits is the AUTO budget, in whole edits, for a prefix of n runes.
+func maxPrefixEdits(n int) int {
+	return int(analysis.AutoFuzziness.MaxEdits(n))
+}
+
+// fuzzyPrefix finds the keys whose prefix is within the edit budget of q
+// (Damerau-Levenshtein). The first rune must match, which keeps the walk to
+// one letter's share of the dictionary. Sorted keys are walked like a trie:
+// DP rows are reused along the common prefix with the previous key, and a
+// whole run is skipped once its prefix can no longer match or is too long to
+// change the outcome. The exact run [skipLo, skipHi) is left out.
+func (d *suggestDict) fuzzyPrefix(q []rune, skipLo, skipHi int) []suggestMatch {
+	maxEdits := maxPrefixEdits(len(q))
+	if maxEdits == 0 {
+		return nil
+	}
+	limit := len(q) + maxEdits
+
+	lo, hi := d.prefixRange(string(q[0]))
+
+	// rows[n] is the DP row after consuming n runes of the current key and
+	// dist[n] the best distance of q to any of the key's first n prefixes.
+	rows := make([][]int, limit+1)
+	for n := range rows {
+		rows[n] = make([]int, len(q)+1)
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	dist := make([]int, limit+1)
+	dist[0] = len(q)
+	valid := 0 // rows[0..valid] belong to prev
+
+	var matches []suggestMatch
+	var prev []rune
+
+	for i := lo; i < hi; {
+		if i >= skipLo && i < skipHi {
+			i = skipHi
+			continue
+		}
+
+		key := d.runes[i]
+		depth := min(commonPrefix(prev, key), valid)
+		prev, valid = key, depth
+
+		// frozen is the depth from which the outcome no longer depends on
+		// the rest of the key: every DP cell is past the budget, or the key
+		// is longer than any prefix that could still match.
+		frozen := 0
+		for n := depth + 1; n <= min(len(key), limit); n++ {
+			row := rows[n]
+			row[0] = n
+			best := row[0]
+			for j := 1; j <= len(q); j++ {
+				cost := 1
+				if q[j-1] == key[n-1] {
+					cost = 0
+				}
+				row[j] = min(rows[n-1][j]+1, row[j-1]+1, rows[n-1][j-1]+cost)
+				if n > 1 && j > 1 && q[j-1] == key[n-2] && q[j-2] == key[n-1] {
+					row[j] = min(row[j], rows[n-2][j-2]+1)
+				}
+				best = min(best, row[j])
+			}
+			dist[n] = min(dist[n-1], row[len(q)])
+			valid = n
+
+			if best > maxEdits {
+				frozen = n
+				break
+			}
+		}
+		if frozen == 0 && len(key) > limit {
+			frozen = limit
+		}
+
+		n, next := min(len(key), limit), i+1
+		runHi := i + 1
+		if frozen > 0 {
+			// Keys before i sharing this prefix were settled already.
+			n = frozen
+			runHi = d.runEnd(i, hi, key[:frozen])
+			next = runHi
+		}
+		if dist[n] <= maxEdits {
+			matches = append(matches, splitAround(i, runHi, skipLo, skipHi, dist[n])...)
+		}
+		i = max(next, i+1)
+	}
+
+	return matches
+}
+
+// runEnd returns the end of the run of keys from i on that start with
+// prefix. Runs are mostly short, so it gallops before bisecting.
+func (d *suggestDict) runEnd(i, hi int, prefix []rune) int {
+	inRun := func(k int) bool {
+		return commonPrefix(d.runes[k], prefix) == len(prefix)
+	}
+
+	lo, step := i+1, 1
+	for lo < hi && inRun(lo) {
+		lo += step
+		step *= 2
+	}
+	from := max(i+1, lo-step/2)
+	return from + sort.Search(min(lo, hi)-from, func(k int) bool { return !inRun(from + k) })
+}
+
+// splitAround removes the exact-match run from a fuzzy run so exact
+// completions keep distance 0.
+func splitAround(lo, hi, skipLo, skipHi, distance int) []suggestMatch {
+	var out []suggestMatch
+	if lo < skipLo {
+		out = append(out, suggestMatch{lo: lo, hi: min(hi, skipLo), distance: distance})
+	}
+	if hi > skipHi {
+		out = append(out, suggestMatch{lo: max(lo, skipHi), hi: hi, distance: distance})
+	}
+	if skipLo >= skipHi && len(out) == 2 {
+		out = out[:1]
+		out[0].hi = hi
+	}
+	return out
+}
+
+func commonPrefix(a, b []rune) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// rebuildSurfaceCounts recomputes term occurrences from stored documents,
+// used after Load.
+func (idx *InMemoryIndex) rebuildSurfaceCounts() {
+	idx.surfaceCounts = make(map[string]int)
+	idx.surfaceTerms = make(map[string]string)
+	for id, doc := range idx.documents {
+		idx.countSurface(doc.Fields[DefaultField], idx.positions[id], 1)
+	}
+	idx.suggestDirty.Store(true)
+}
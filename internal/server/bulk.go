@@ -0,0 +1,141 @@
+package server
+
+import (
+	"hash/fnv"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shramanb113/ZENITH/gen/go/zenithproto"
+	"github.com/shramanb113/ZENITH/internal/index"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bulkQueueSize is how many operations may wait for each bulk worker. When
+// a queue is full the stream is not read, so a fast client is held back by
+// gRPC flow control instead of filling the server's memory.
+const bulkQueueSize = 64
+
+type bulkItem struct {
+	seq int64
+	op  *zenithproto.BulkOperation
+}
+
+// BulkIndex applies a stream of writes through a pool of workers. Every
+// operation on one id goes to the same worker, so they are applied in the
+// order they were sent. A failed operation is reported in its result and
+// does not stop the stream.
+func (s *ZenithServer) BulkIndex(stream grpc.ClientStreamingServer[zenithproto.BulkOperation, zenithproto.BulkIndexResponse]) error {
+
+	start := time.Now()
+	workers := s.BulkWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var (
+		mu      sync.Mutex
+		results []*zenithproto.BulkItemResult
+		wg      sync.WaitGroup
+	)
+	queues := make([]chan bulkItem, workers)
+	for i := range queues {
+		queues[i] = make(chan bulkItem, bulkQueueSize)
+		wg.Add(1)
+		go func(queue <-chan bulkItem) {
+			defer wg.Done()
+			for item := range queue {
+				result := &zenithproto.BulkItemResult{Seq: item.seq, Id: item.op.Id}
+				if err := s.applyBulk(item.op); err != nil {
+					st := status.Convert(err)
+					result.Code = int32(st.Code())
+					result.Error = st.Message()
+				}
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+			}
+		}(queues[i])
+	}
+
+	var seq int64
+	var recvErr error
+	for {
+		op, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			recvErr = err
+			break
+		}
+		queues[bulkShard(op.Id, workers)] <- bulkItem{seq: seq, op: op}
+		seq++
+	}
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+	if recvErr != nil {
+		return recvErr
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Seq < results[j].Seq })
+	resp := &zenithproto.BulkIndexResponse{
+		Items:  results,
+		Total:  int64(len(results)),
+		TookMs: time.Since(start).Milliseconds(),
+	}
+	for _, r := range results {
+		if r.Code == int32(codes.OK) {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+	return stream.SendAndClose(resp)
+}
+
+func bulkShard(id string, workers int) int {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return int(h.Sum32() % uint32(workers))
+}
+
+func (s *ZenithServer) applyBulk(op *zenithproto.BulkOperation) error {
+	if op.Id == "" {
+		return status.Error(codes.InvalidArgument, "id is required")
+	}
+	if op.Type != zenithproto.BulkOperation_DELETE {
+		if err := validateDocument(op.Language, op.Fields); err != nil {
+			return err
+		}
+	}
+	idx, err := s.lookup(op.Index)
+	if err != nil {
+		return err
+	}
+
+	switch op.Type {
+	case zenithproto.BulkOperation_INDEX:
+		err = idx.Add(op.Id, op.Data, index.WithTitle(op.Title), index.WithPopularity(op.GetPopularity()), index.WithLanguage(op.Language), index.WithFields(op.Fields))
+	case zenithproto.BulkOperation_UPDATE:
+		opts := []index.AddOption{index.WithTitle(op.Title), index.WithLanguage(op.Language), index.WithFields(op.Fields)}
+		if op.Popularity != nil {
+			opts = append(opts, index.WithPopularity(*op.Popularity))
+		}
+		err = idx.Update(op.Id, op.Data, opts...)
+	case zenithproto.BulkOperation_DELETE:
+		err = idx.Delete(op.Id)
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown operation %v", op.Type)
+	}
+	if err != nil {
+		return documentError(err)
+	}
+	return nil
+}
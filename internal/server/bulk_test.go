@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/shramanb113/ZENITH/gen/go/zenithproto"
+	"github.com/shramanb113/ZENITH/internal/index"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type bulkStream struct {
+	grpc.ServerStream
+	ops  []*zenithproto.BulkOperation
+	resp *zenithproto.BulkIndexResponse
+}
+
+func (s *bulkStream) Context() context.Context { return context.Background() }
+
+func (s *bulkStream) Recv() (*zenithproto.BulkOperation, error) {
+	if len(s.ops) == 0 {
+		return nil, io.EOF
+	}
+	op := s.ops[0]
+	s.ops = s.ops[1:]
+	return op, nil
+}
+
+func (s *bulkStream) SendAndClose(resp *zenithproto.BulkIndexResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func TestBulkIndexAppliesEachIDInStreamOrder(t *testing.T) {
+	catalog, err := index.OpenCatalog(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { catalog.Close() })
+	idx, err := catalog.Create("docs", index.IndexSettings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every id is written once per round, interleaved with the other ids,
+	// and the even ones are deleted at the end; only the last write of each
+	// may survive.
+	const ids = 40
+	rounds := []string{"alpha", "bravo", "delta", "echo"}
+	var ops []*zenithproto.BulkOperation
+	for _, word := range rounds {
+		for i := range ids {
+			ops = append(ops, &zenithproto.BulkOperation{Index: "docs", Id: fmt.Sprintf("doc%d", i), Data: word})
+		}
+	}
+	for i := 0; i < ids; i += 2 {
+		ops = append(ops, &zenithproto.BulkOperation{Type: zenithproto.BulkOperation_DELETE, Index: "docs", Id: fmt.Sprintf("doc%d", i)})
+	}
+	ops = append(ops, &zenithproto.BulkOperation{Index: "docs", Data: "no id"})
+	total := len(ops)
+
+	s := &ZenithServer{Indexes: catalog, BulkWorkers: 4}
+	stream := &bulkStream{ops: ops}
+	if err := s.BulkIndex(stream); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := stream.resp
+	if resp.Total != int64(total) || resp.Succeeded != int64(total-1) || resp.Failed != 1 {
+		t.Errorf("got total %d, succeeded %d, failed %d; want %d, %d, 1", resp.Total, resp.Succeeded, resp.Failed, total, total-1)
+	}
+	if len(resp.Items) != total {
+		t.Fatalf("got %d items, want one per operation (%d)", len(resp.Items), total)
+	}
+	for i, item := range resp.Items {
+		if item.Seq != int64(i) {
+			t.Fatalf("item %d has seq %d", i, item.Seq)
+		}
+	}
+	if last := resp.Items[total-1]; last.Code != int32(codes.InvalidArgument) {
+		t.Errorf("operation without an id: got code %d, want InvalidArgument", last.Code)
+	}
+
+	var want []string
+	for i := 1; i < ids; i += 2 {
+		want = append(want, fmt.Sprintf("doc%d", i))
+	}
+	got := idx.SearchAND([]string{"echo"})
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("documents holding the last write: got %v, want %v", got, want)
+	}
+	for _, word := range rounds[:len(rounds)-1] {
+		if stale := idx.SearchAND([]string{word}); len(stale) > 0 {
+			t.Errorf("documents still holding %q: %v", word, stale)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/shramanb113/ZENITH/gen/go/zenithproto"
+	"github.com/shramanb113/ZENITH/internal/index"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ZenithServer) CreateIndex(ctx context.Context, req *zenithproto.CreateIndexRequest) (*zenithproto.CreateIndexResponse, error) {
+
+	name := req.Index
+	if name == "" {
+		name = index.DefaultIndex
+	}
+
+	var settings index.IndexSettings
+	if req.Schema != nil {
+		fields, err := fieldsFromProto(req.Schema.Fields)
+		if err != nil {
+			return nil, err
+		}
+		settings.Schema = &index.Schema{Fields: fields}
+	}
+	if req.ConfigJson != "" {
+		if err := json.Unmarshal([]byte(req.ConfigJson), &settings.Config); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "parse config_json: %v", err)
+		}
+	}
+
+	idx, err := s.Indexes.Create(name, settings)
+	if err != nil {
+		return nil, schemaError(err)
+	}
+
+	resp := &zenithproto.CreateIndexResponse{}
+	if schema, ok := idx.Schema(); ok {
+		resp.Schema = schemaToProto(schema)
+	}
+	return resp, nil
+}
+
+func (s *ZenithServer) DeleteIndex(ctx context.Context, req *zenithproto.DeleteIndexRequest) (*zenithproto.DeleteIndexResponse, error) {
+
+	if req.Index == "" {
+		return nil, status.Error(codes.InvalidArgument, "index is required")
+	}
+	if err := s.Indexes.Delete(req.Index); err != nil {
+		return nil, catalogError(err)
+	}
+
+	return &zenithproto.DeleteIndexResponse{}, nil
+}
+
+func (s *ZenithServer) ListIndexes(ctx context.Context, req *zenithproto.ListIndexesRequest) (*zenithproto.ListIndexesResponse, error) {
+
+	resp := &zenithproto.ListIndexesResponse{}
+	for _, info := range s.Indexes.List() {
+		resp.Indexes = append(resp.Indexes, &zenithproto.IndexInfo{
+			Name:          info.Name,
+			Documents:     int64(info.Documents),
+			Pending:       int64(info.Pending),
+			SchemaVersion: info.SchemaVersion,
+			Aliases:       info.Aliases,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *ZenithServer) UpdateAliases(ctx context.Context, req *zenithproto.UpdateAliasesRequest) (*zenithproto.UpdateAliasesResponse, error) {
+
+	actions := make([]index.AliasAction, 0, len(req.Actions))
+	for _, a := range req.Actions {
+		switch a.Type {
+		case zenithproto.AliasAction_ADD, zenithproto.AliasAction_REMOVE:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown alias action %v", a.Type)
+		}
+		actions = append(actions, index.AliasAction{
+			Alias:  a.Alias,
+			Index:  a.Index,
+			Remove: a.Type == zenithproto.AliasAction_REMOVE,
+		})
+	}
+
+	err := s.Indexes.UpdateAliases(actions)
+	switch {
+	case errors.Is(err, index.ErrInvalidIndexName):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, index.ErrAliasNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, catalogError(err)
+	}
+
+	return &zenithproto.UpdateAliasesResponse{}, nil
+}
+
+func (s *ZenithServer) Reindex(ctx context.Context, req *zenithproto.ReindexRequest) (*zenithproto.ReindexResponse, error) {
+
+	if req.Source == "" || req.Dest == "" {
+		return nil, status.Error(codes.InvalidArgument, "source and dest are required")
+	}
+	job, err := s.Indexes.Reindex(req.Source, req.Dest)
+	if errors.Is(err, index.ErrReindexIntoSelf) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, catalogError(err)
+	}
+
+	return &zenithproto.ReindexResponse{Job: reindexJobToProto(job.Status())}, nil
+}
+
+func (s *ZenithServer) GetReindexJob(ctx context.Context, req *zenithproto.GetReindexJobRequest) (*zenithproto.ReindexJob, error) {
+
+	job, err := s.Indexes.Job(req.Id)
+	if errors.Is(err, index.ErrJobNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return reindexJobToProto(job.Status()), nil
+}
+
+func reindexJobToProto(st index.ReindexStatus) *zenithproto.ReindexJob {
+	out := &zenithproto.ReindexJob{
+		Id:                st.ID,
+		Source:            st.Source,
+		Dest:              st.Dest,
+		Total:             int64(st.Total),
+		Copied:            int64(st.Copied),
+		Failed:            int64(st.Failed),
+		Skipped:           int64(st.Skipped),
+		Errors:            st.Errors,
+		PendingEmbeddings: int64(st.PendingEmbeddings),
+		StartedUnix:       st.Started.Unix(),
+	}
+	switch st.State {
+	case index.ReindexRunning:
+		out.State = zenithproto.ReindexState_REINDEX_RUNNING
+	case index.ReindexDone:
+		out.State = zenithproto.ReindexState_REINDEX_DONE
+		out.FinishedUnix = st.Finished.Unix()
+	case index.ReindexCanceled:
+		out.State = zenithproto.ReindexState_REINDEX_CANCELED
+		out.FinishedUnix = st.Finished.Unix()
+	}
+	return out
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/shramanb113/ZENITH/gen/go/zenithproto"
+	"github.com/shramanb113/ZENITH/internal/index"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ZenithServer) PutMapping(ctx context.Context, req *zenithproto.PutMappingRequest) (*zenithproto.PutMappingResponse, error) {
+
+	fields, err := fieldsFromProto(req.Fields)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := s.lookup(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := idx.PutMapping(fields)
+	if err != nil {
+		return nil, schemaError(err)
+	}
+
+	return &zenithproto.PutMappingResponse{Schema: schemaToProto(schema)}, nil
+}
+
+func (s *ZenithServer) GetMapping(ctx context.Context, req *zenithproto.GetMappingRequest) (*zenithproto.GetMappingResponse, error) {
+
+	idx, err := s.lookup(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	schema, ok := idx.Schema()
+	if !ok {
+		return nil, status.Error(codes.NotFound, index.ErrNoSchema.Error())
+	}
+
+	return &zenithproto.GetMappingResponse{Schema: schemaToProto(schema)}, nil
+}
+
+func schemaError(err error) error {
+	switch {
+	case errors.Is(err, index.ErrInvalidSchema), errors.Is(err, index.ErrInvalidConfig), errors.Is(err, index.ErrInvalidIndexName):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, index.ErrIndexExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, index.ErrIncompatibleSchema), errors.Is(err, index.ErrNoSchema):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+var fieldTypes = map[zenithproto.FieldType]index.FieldType{
+	zenithproto.FieldType_FIELD_TEXT:    index.FieldText,
+	zenithproto.FieldType_FIELD_KEYWORD: index.FieldKeyword,
+	zenithproto.FieldType_FIELD_NUMBER:  index.FieldNumber,
+	zenithproto.FieldType_FIELD_VECTOR:  index.FieldVector,
+}
+
+var vectorMetrics = map[zenithproto.VectorMetric]index.VectorMetric{
+	zenithproto.VectorMetric_METRIC_COSINE:      index.MetricCosine,
+	zenithproto.VectorMetric_METRIC_DOT_PRODUCT: index.MetricDot,
+	zenithproto.VectorMetric_METRIC_L2:          index.MetricL2,
+}
+
+func fieldsFromProto(mappings []*zenithproto.FieldMapping) (map[string]index.FieldSchema, error) {
+	fields := make(map[string]index.FieldSchema, len(mappings))
+	for _, m := range mappings {
+		if _, dup := fields[m.Name]; dup {
+			return nil, status.Errorf(codes.InvalidArgument, "field %q declared twice", m.Name)
+		}
+		f, err := fieldFromProto(m)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		fields[m.Name] = f
+	}
+	return fields, nil
+}
+
+func fieldFromProto(m *zenithproto.FieldMapping) (index.FieldSchema, error) {
+	f := index.FieldSchema{
+		Analyzer:       m.Analyzer,
+		SearchAnalyzer: m.SearchAnalyzer,
+		Stored:         m.Stored == nil || *m.Stored,
+		Indexed:        m.Indexed == nil || *m.Indexed,
+		Dims:           int(m.Dims),
+	}
+	if n := m.Ngrams; n != nil {
+		f.NGrams = &index.NGramConfig{
+			EdgeMin:  int(n.EdgeMin),
+			EdgeMax:  int(n.EdgeMax),
+			InfixMin: int(n.InfixMin),
+			InfixMax: int(n.InfixMax),
+		}
+	}
+
+	t, ok := fieldTypes[m.Type]
+	if !ok {
+		return f, fmt.Errorf("field %q: unknown type %v", m.Name, m.Type)
+	}
+	f.Type = t
+
+	switch {
+	case m.Metric == zenithproto.VectorMetric_METRIC_UNSPECIFIED && t == index.FieldVector:
+		f.Metric = index.MetricCosine
+	case m.Metric != zenithproto.VectorMetric_METRIC_UNSPECIFIED:
+		metric, ok := vectorMetrics[m.Metric]
+		if !ok {
+			return f, fmt.Errorf("field %q: unknown metric %v", m.Name, m.Metric)
+		}
+		f.Metric = metric
+	}
+	return f, nil
+}
+
+func schemaToProto(s index.Schema) *zenithproto.Schema {
+	out := &zenithproto.Schema{Version: s.Version}
+
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := s.Fields[name]
+		m := &zenithproto.FieldMapping{
+			Name:           name,
+			Analyzer:       f.Analyzer,
+			SearchAnalyzer: f.SearchAnalyzer,
+			Stored:         &f.Stored,
+			Indexed:        &f.Indexed,
+			Dims:           int32(f.Dims),
+		}
+		if n := f.NGrams; n != nil {
+			m.Ngrams = &zenithproto.NGrams{
+				EdgeMin:  int32(n.EdgeMin),
+				EdgeMax:  int32(n.EdgeMax),
+				InfixMin: int32(n.InfixMin),
+				InfixMax: int32(n.InfixMax),
+			}
+		}
+		for pt, t := range fieldTypes {
+			if t == f.Type {
+				m.Type = pt
+			}
+		}
+		for pm, metric := range vectorMetrics {
+			if metric == f.Metric {
+				m.Metric = pm
+			}
+		}
+		out.Fields = append(out.Fields, m)
+	}
+	return out
+}
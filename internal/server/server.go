@@ -2,23 +2,67 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/shramanb113/ZENITH/gen/go/zenithproto"
 	"github.com/shramanb113/ZENITH/internal/analysis"
 	"github.com/shramanb113/ZENITH/internal/index"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ZenithServer struct {
 	zenithproto.UnimplementedSearchServiceServer
-	Index     *index.InMemoryIndex
-	Tokenizer *analysis.StandardTokenizer
+	Indexes *index.Catalog
+
+	// BulkWorkers is how many operations of a BulkIndex stream are applied
+	// at once; 0 uses GOMAXPROCS.
+	BulkWorkers int
 }
 
-func (s *ZenithServer) IndexDocuments(ctx context.Context, req *zenithproto.IndexRequest) (*zenithproto.IndexResponse, error) {
+// lookup resolves the index a request names.
+func (s *ZenithServer) lookup(name string) (*index.InMemoryIndex, error) {
+	idx, err := s.Indexes.Get(name)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return idx, nil
+}
+
+// resolve returns every index a request reads from: the named one, or all
+// those behind an alias.
+func (s *ZenithServer) resolve(name string) ([]*index.InMemoryIndex, error) {
+	indexes, err := s.Indexes.Resolve(name)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return indexes, nil
+}
 
-	tokens := s.Tokenizer.Tokenize(req.Data)
+func catalogError(err error) error {
+	switch {
+	case errors.Is(err, index.ErrIndexNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, index.ErrAmbiguousAlias):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (s *ZenithServer) IndexDocuments(ctx context.Context, req *zenithproto.IndexRequest) (*zenithproto.IndexResponse, error) {
 
-	s.Index.Add(req.Id, req.Data, tokens)
+	if err := validateDocument(req.Language, req.Fields); err != nil {
+		return nil, err
+	}
+	idx, err := s.lookup(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	err = idx.Add(req.Id, req.Data, index.WithTitle(req.Title), index.WithPopularity(req.Popularity), index.WithLanguage(req.Language), index.WithFields(req.Fields))
+	if err != nil {
+		return nil, documentError(err)
+	}
 
 	return &zenithproto.IndexResponse{
 		Status:  true,
@@ -28,21 +72,342 @@ func (s *ZenithServer) IndexDocuments(ctx context.Context, req *zenithproto.Inde
 
 func (s *ZenithServer) Search(ctx context.Context, req *zenithproto.SearchRequest) (*zenithproto.SearchResponse, error) {
 
-	tokens := s.Tokenizer.Tokenize(req.Query)
+	if err := index.ValidateQuery(req.Query); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts, err := searchOptions(req.Fusion)
+	if err != nil {
+		return nil, err
+	}
+	opts.Explain = req.Explain
+	if err := validateLanguage(req.Language); err != nil {
+		return nil, err
+	}
+	opts.Language = req.Language
+	if req.Fuzziness != "" {
+		fuzziness, err := analysis.ParseFuzziness(req.Fuzziness)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts.Fuzziness = &fuzziness
+	}
+	if h := req.Highlight; h != nil {
+		opts.Highlight = &index.HighlightConfig{
+			PreTag:            h.PreTag,
+			PostTag:           h.PostTag,
+			FragmentSize:      int(h.FragmentSize),
+			NumberOfFragments: int(h.NumberOfFragments),
+		}
+	}
 
-	results := s.Index.Search(req.Query, tokens)
+	switch req.SpellCheck {
+	case zenithproto.SpellCheck_SPELL_CHECK_OFF, zenithproto.SpellCheck_SPELL_CHECK_SUGGEST, zenithproto.SpellCheck_SPELL_CHECK_AUTO_CORRECT:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown spell check mode %v", req.SpellCheck)
+	}
+
+	indexes, err := s.resolve(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	results := searchAll(ctx, indexes, req.Query, opts)
+
+	var suggestion string
+	autoCorrected := false
+	if req.SpellCheck != zenithproto.SpellCheck_SPELL_CHECK_OFF {
+		if corrected, ok := correctQuery(indexes, req.Query); ok {
+			suggestion = corrected
+			if req.SpellCheck == zenithproto.SpellCheck_SPELL_CHECK_AUTO_CORRECT && results.KeywordHits == 0 {
+				results = searchAll(ctx, indexes, corrected, opts)
+				autoCorrected = true
+			}
+		}
+	}
 
 	var protoResults []*zenithproto.SearchResult
 
-	for _, res := range results {
+	for _, res := range results.Hits {
 		protoResults = append(protoResults, &zenithproto.SearchResult{
-			Id:    res.ID,
-			Score: res.Score,
+			Id:          res.ID,
+			Score:       res.Score,
+			Explanation: explanationToProto(res.Explanation),
+			Highlights:  res.Highlights,
 		})
 	}
 
 	return &zenithproto.SearchResponse{
-		Results: protoResults,
+		Results:            protoResults,
+		SemanticSkipped:    results.SemanticSkipped,
+		DegradedReason:     results.SkipReason,
+		Suggestion:         suggestion,
+		AutoCorrected:      autoCorrected,
+		Language:           results.Language,
+		ExpansionTruncated: results.ExpansionTruncated,
+	}, nil
+
+}
+
+func (s *ZenithServer) EmbeddingStatus(ctx context.Context, req *zenithproto.EmbeddingStatusRequest) (*zenithproto.EmbeddingStatusResponse, error) {
+
+	idx, err := s.lookup(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	stats := idx.PipelineStats()
+
+	resp := &zenithproto.EmbeddingStatusResponse{
+		Pending:   int64(stats.Pending),
+		Converted: int64(stats.Converted),
+		Queued:    int64(stats.Queued),
+		Failed:    int64(stats.Failed),
+	}
+
+	if stats.Cache != nil {
+		resp.Cache = &zenithproto.EmbeddingCacheStats{
+			Hits:     stats.Cache.Hits,
+			DiskHits: stats.Cache.DiskHits,
+			Misses:   stats.Cache.Misses,
+			Entries:  int64(stats.Cache.Entries),
+		}
+	}
+
+	for _, f := range stats.Failures {
+		resp.Failures = append(resp.Failures, &zenithproto.EmbeddingFailure{
+			Id:              f.ID,
+			Attempts:        int32(f.Attempts),
+			Error:           f.Err,
+			LastAttemptUnix: f.LastAttempt.Unix(),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *ZenithServer) Explain(ctx context.Context, req *zenithproto.ExplainRequest) (*zenithproto.ExplainResponse, error) {
+
+	if err := index.ValidateQuery(req.Query); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts, err := searchOptions(req.Fusion)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLanguage(req.Language); err != nil {
+		return nil, err
+	}
+	opts.Language = req.Language
+
+	indexes, err := s.resolve(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	// Behind an alias the document is explained in the index holding it.
+	var explanation *index.Explanation
+	var rank int
+	for _, idx := range indexes {
+		explanation, rank, err = idx.Explain(ctx, req.Query, req.Id, opts)
+		if !errors.Is(err, index.ErrDocumentNotFound) {
+			break
+		}
+	}
+	if errors.Is(err, index.ErrDocumentNotFound) {
+		return nil, status.Errorf(codes.NotFound, "document %q not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &zenithproto.ExplainResponse{
+		Rank:        int32(rank),
+		Explanation: explanationToProto(explanation),
 	}, nil
+}
+
+func (s *ZenithServer) Suggest(ctx context.Context, req *zenithproto.SuggestRequest) (*zenithproto.SuggestResponse, error) {
+
+	opts := index.SuggestOptions{
+		Size:  int(req.Size),
+		Fuzzy: req.Fuzzy,
+	}
 
+	switch req.Source {
+	case zenithproto.SuggestSource_SUGGEST_TERMS:
+		opts.Source = index.SuggestTerms
+	case zenithproto.SuggestSource_SUGGEST_TITLES:
+		opts.Source = index.SuggestTitles
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown suggest source %v", req.Source)
+	}
+
+	idx, err := s.lookup(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	resp := &zenithproto.SuggestResponse{}
+	for _, sug := range idx.Suggest(req.Prefix, opts) {
+		resp.Suggestions = append(resp.Suggestions, &zenithproto.Suggestion{
+			Text:     sug.Text,
+			Id:       sug.ID,
+			Weight:   sug.Weight,
+			Distance: int32(sug.Distance),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *ZenithServer) Analyze(ctx context.Context, req *zenithproto.AnalyzeRequest) (*zenithproto.AnalyzeResponse, error) {
+
+	if req.Analyzer != "" && req.Field != "" {
+		return nil, status.Error(codes.InvalidArgument, "set either analyzer or field, not both")
+	}
+
+	idx, err := s.lookup(req.Index)
+	if err != nil {
+		return nil, err
+	}
+	name, tokens, err := idx.Analyze(req.Text, index.AnalyzeOptions{
+		Analyzer: req.Analyzer,
+		Field:    req.Field,
+		Search:   req.Search,
+	})
+	if errors.Is(err, index.ErrUnknownAnalyzer) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &zenithproto.AnalyzeResponse{Analyzer: name}
+	for _, t := range tokens {
+		out := &zenithproto.AnalyzedToken{
+			Term:        t.Term,
+			Text:        t.Text,
+			Start:       int32(t.Start),
+			End:         int32(t.End),
+			Position:    int32(t.Position),
+			Keyword:     t.Keyword,
+			EdgeNgrams:  t.EdgeNgrams,
+			InfixNgrams: t.InfixNgrams,
+		}
+		for _, p := range t.Phonetic {
+			out.Phonetic = append(out.Phonetic, &zenithproto.PhoneticCode{Encoder: p.Encoder, Code: p.Code})
+		}
+		resp.Tokens = append(resp.Tokens, out)
+	}
+
+	return resp, nil
+}
+
+func searchAll(ctx context.Context, indexes []*index.InMemoryIndex, query string, opts index.SearchOptions) index.SearchResults {
+	if len(indexes) == 1 {
+		return indexes[0].Search(ctx, query, opts)
+	}
+	results := make([]index.SearchResults, len(indexes))
+	for i, idx := range indexes {
+		results[i] = idx.Search(ctx, query, opts)
+	}
+	return index.MergeResults(results...)
+}
+
+func correctQuery(indexes []*index.InMemoryIndex, query string) (string, bool) {
+	for _, idx := range indexes {
+		if corrected, ok := idx.CorrectQuery(query); ok {
+			return corrected, true
+		}
+	}
+	return "", false
+}
+
+func searchOptions(fusion *zenithproto.Fusion) (index.SearchOptions, error) {
+	var opts index.SearchOptions
+	if fusion != nil {
+		cfg, err := fusionFromProto(fusion)
+		if err != nil {
+			return opts, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts.Fusion = &cfg
+	}
+	return opts, nil
+}
+
+func explanationToProto(e *index.Explanation) *zenithproto.Explanation {
+	if e == nil {
+		return nil
+	}
+
+	out := &zenithproto.Explanation{
+		Description: e.Description,
+		Value:       e.Value,
+	}
+	for _, d := range e.Details {
+		out.Details = append(out.Details, explanationToProto(d))
+	}
+
+	return out
+}
+
+func fusionFromProto(f *zenithproto.Fusion) (index.FusionConfig, error) {
+	cfg := index.FusionConfig{
+		K:             f.K,
+		KeywordWeight: f.KeywordWeight,
+		VectorWeight:  f.VectorWeight,
+	}
+
+	switch f.Method {
+	case zenithproto.FusionMethod_FUSION_DEFAULT:
+	case zenithproto.FusionMethod_FUSION_RRF:
+		cfg.Method = index.FusionRRF
+	case zenithproto.FusionMethod_FUSION_CONVEX:
+		cfg.Method = index.FusionConvex
+	case zenithproto.FusionMethod_FUSION_DBSF:
+		cfg.Method = index.FusionDBSF
+	default:
+		return cfg, fmt.Errorf("unknown fusion method %v", f.Method)
+	}
+
+	switch f.Normalization {
+	case zenithproto.ScoreNormalization_NORMALIZATION_DEFAULT:
+	case zenithproto.ScoreNormalization_NORMALIZATION_MIN_MAX:
+		cfg.Normalization = index.NormalizeMinMax
+	case zenithproto.ScoreNormalization_NORMALIZATION_Z_SCORE:
+		cfg.Normalization = index.NormalizeZScore
+	default:
+		return cfg, fmt.Errorf("unknown normalization %v", f.Normalization)
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// validateDocument checks the parts of a write the index cannot.
+func validateDocument(lang string, fields map[string]string) error {
+	if err := validateLanguage(lang); err != nil {
+		return err
+	}
+	for _, name := range []string{index.DefaultField, index.TitleField} {
+		if _, ok := fields[name]; ok {
+			return status.Errorf(codes.InvalidArgument, "set %s through its own request field", name)
+		}
+	}
+	return nil
+}
+
+func documentError(err error) error {
+	switch {
+	case errors.Is(err, index.ErrInvalidDocument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, index.ErrDocumentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func validateLanguage(lang string) error {
+	if lang == "" {
+		return nil
+	}
+	if _, err := analysis.CanonicalLanguage(lang); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
 }
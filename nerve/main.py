@@ -5,10 +5,38 @@ from sentence_transformers import SentenceTransformer
 app = FastAPI()
 model = SentenceTransformer('all-MiniLM-L6-v2')
 
+MAX_BATCH = 256
+
 class TextRequest(BaseModel):
     text: str
 
+class BatchRequest(BaseModel):
+    texts: list[str]
+
 @app.post("/embed")
 async def embed(request: TextRequest):
     vector = model.encode(request.text).tolist()
-    return {"embedding": vector}
\ No newline at end of file
+    return {"embedding": vector}
+
+@app.post("/embed_batch")
+async def embed_batch(request: BatchRequest):
+    texts = request.texts[:MAX_BATCH]
+    embeddings = [None] * len(request.texts)
+    errors = [None] * len(request.texts)
+
+    for i in range(len(texts), len(request.texts)):
+        errors[i] = f"batch larger than {MAX_BATCH} items"
+
+    try:
+        for i, vector in enumerate(model.encode(texts)):
+            embeddings[i] = vector.tolist()
+    except Exception:
+        # Fall back to one text at a time so a single bad input
+        # only fails its own slot.
+        for i, text in enumerate(texts):
+            try:
+                embeddings[i] = model.encode(text).tolist()
+            except Exception as exc:
+                errors[i] = str(exc)
+
+    return {"embeddings": embeddings, "errors": errors}